@@ -0,0 +1,325 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Unbounded is the end index of a range that runs to the edge of the sheet,
+// as in "A:A" (no row bound) or "5:10" (no column bound).
+//
+// The Sheets API expresses the same thing by leaving the end index out,
+// which is indistinguishable from zero once decoded; ranges coming from or
+// going to the API are converted with gridRangeFromAPI and apiGridRange.
+const Unbounded int64 = -1
+
+var ErrBadA1 = errors.New("bad A1 notation")
+
+const (
+	maxA1ColumnLetters = 3     // ZZZ is well past the Sheets column limit
+	lastA1Column       = 18277 // ZZZ
+	maxA1RowDigits     = 10    // keeps row numbers far from overflow
+)
+
+// ParseA1 parses an A1 reference: A1, B6:J10, B2:A1 (reversed), 5:10 (full
+// rows), B:E (full columns), A1:A, Sheet1!A1 and 'My Sheet'!A1 are all
+// accepted; letters are case insensitive. An unquoted sheet name is
+// everything before the last !, so My Sheet!A1 is accepted too.
+//
+// The result is zero based with exclusive ends, like a sheets.GridRange
+// (SheetId is left zero; only the sheet name is known here). Missing bounds
+// are Unbounded. sheetName is "" when the reference has no sheet part.
+func ParseA1(a1 string) (sheetName string, gridRange *sheets.GridRange, err error) {
+	sheetName, rest, err := splitA1Sheet(a1)
+	if err != nil {
+		return "", nil, err
+	}
+	spl := strings.Split(rest, ":")
+	var start, end a1Ref
+	switch len(spl) {
+	case 1:
+		start, err = parseA1Ref(spl[0])
+		if err != nil {
+			return "", nil, err
+		}
+		if !start.hasRow || !start.hasCol {
+			return "", nil, ErrBadA1 // "A" or "1" on its own
+		}
+		end = start
+	case 2:
+		start, err = parseA1Ref(spl[0])
+		if err != nil {
+			return "", nil, err
+		}
+		end, err = parseA1Ref(spl[1])
+		if err != nil {
+			return "", nil, err
+		}
+	default:
+		return "", nil, ErrBadA1
+	}
+
+	startRow, endRow := a1Span(start.row, start.hasRow, end.row, end.hasRow)
+	startCol, endCol := a1Span(start.col, start.hasCol, end.col, end.hasCol)
+	gridRange = &sheets.GridRange{
+		StartRowIndex:    startRow,
+		EndRowIndex:      endRow,
+		StartColumnIndex: startCol,
+		EndColumnIndex:   endCol,
+	}
+	return sheetName, gridRange, nil
+}
+
+// a1Span turns the two (inclusive) coordinates of a range along one axis
+// into a start and exclusive end. A missing start is the first row/column,
+// a missing end is Unbounded, and reversed coordinates are swapped.
+func a1Span(start int64, hasStart bool, end int64, hasEnd bool) (int64, int64) {
+	if !hasStart {
+		start = 0
+	}
+	if !hasEnd {
+		return start, Unbounded
+	}
+	if end < start {
+		start, end = end, start
+	}
+	return start, end + 1
+}
+
+// splitA1Sheet separates an optional (possibly quoted) sheet name from the
+// cell part of an A1 reference.
+func splitA1Sheet(a1 string) (sheetName, rest string, err error) {
+	if strings.HasPrefix(a1, "'") {
+		var name strings.Builder
+		for i := 1; i < len(a1); i++ {
+			switch {
+			case a1[i] != '\'':
+				name.WriteByte(a1[i])
+			case i+1 < len(a1) && a1[i+1] == '\'':
+				name.WriteByte('\'') // '' is an escaped quote
+				i++
+			case i+1 < len(a1) && a1[i+1] == '!' && name.Len() > 0:
+				return name.String(), a1[i+2:], nil
+			default:
+				return "", "", ErrBadA1
+			}
+		}
+		return "", "", ErrBadA1 // unclosed quote
+	}
+	// Sheets itself quotes names that aren't plain, but names written by
+	// hand ("My Sheet!A1") or sent back by the API are taken as they are.
+	bang := strings.LastIndexByte(a1, '!')
+	if bang < 0 {
+		return "", a1, nil
+	}
+	sheetName, rest = a1[:bang], a1[bang+1:]
+	if sheetName == "" {
+		return "", "", ErrBadA1
+	}
+	return sheetName, rest, nil
+}
+
+// isPlainSheetName reports whether name can be written in A1 notation
+// without quotes; see QuoteSheetName.
+func isPlainSheetName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// a1Ref is one side of an A1 range: a cell (B6), a column (B) or a row (6).
+// Coordinates are zero based.
+type a1Ref struct {
+	row, col       int64
+	hasRow, hasCol bool
+}
+
+func parseA1Ref(ref string) (res a1Ref, err error) {
+	i := 0
+	for i < len(ref) && isA1Letter(ref[i]) {
+		i++
+	}
+	letters, digits := ref[:i], ref[i:]
+	if letters == "" && digits == "" {
+		return res, ErrBadA1
+	}
+	if letters != "" {
+		res.col = ParseColumn(letters)
+		if res.col < 0 {
+			return res, ErrBadA1
+		}
+		res.hasCol = true
+	}
+	if digits != "" {
+		res.row = ParseRow(digits)
+		if res.row < 0 {
+			return res, ErrBadA1
+		}
+		res.hasRow = true
+	}
+	return res, nil
+}
+
+func isA1Letter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// ParseColumn converts column letters (A, Z, AA, AAA, ...) to a zero based
+// index. It returns -1 if colName isn't a column.
+func ParseColumn(colName string) (col int64) {
+	if len(colName) == 0 || len(colName) > maxA1ColumnLetters {
+		return -1
+	}
+	for i := 0; i < len(colName); i++ {
+		c := colName[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		case c >= 'A' && c <= 'Z':
+		default:
+			return -1
+		}
+		col = col*26 + int64(c-'A'+1)
+	}
+	return col - 1
+}
+
+// FormatColumn converts a zero based column index to letters.
+// It returns "" for negative indexes, including Unbounded.
+func FormatColumn(col int64) (colName string) {
+	if col < 0 {
+		return ""
+	}
+	buf := []byte{}
+	for col >= 0 {
+		buf = append([]byte{byte('A' + col%26)}, buf...)
+		col = col/26 - 1
+	}
+	return string(buf)
+}
+
+// ParseRow converts a one based row number to a zero based index.
+// It returns -1 if rowNum isn't a row number.
+func ParseRow(rowNum string) (row int64) {
+	if len(rowNum) == 0 || len(rowNum) > maxA1RowDigits {
+		return -1
+	}
+	for i := 0; i < len(rowNum); i++ {
+		c := rowNum[i]
+		if c < '0' || c > '9' {
+			return -1
+		}
+		row = row*10 + int64(c-'0')
+	}
+	if row == 0 {
+		return -1
+	}
+	return row - 1
+}
+
+// FormatRow converts a zero based row index to a one based row number.
+// It returns "" for negative indexes, including Unbounded.
+func FormatRow(row int64) string {
+	if row < 0 {
+		return ""
+	}
+	return strconv.FormatInt(row+1, 10)
+}
+
+// FormatA1 is the inverse of ParseA1. Full rows and columns come out as
+// 5:10 and B:E, single cells as A1, and the sheet name is quoted when it
+// needs to be. A range that is unbounded both ways is just the sheet name.
+func FormatA1(sheetName string, gridRange *sheets.GridRange) (a1 string) {
+	if sheetName != "" {
+		a1 = QuoteSheetName(sheetName) + "!"
+	}
+	r0, r1 := gridRange.StartRowIndex, gridRange.EndRowIndex
+	c0, c1 := gridRange.StartColumnIndex, gridRange.EndColumnIndex
+	switch {
+	case r1 == Unbounded && c1 == Unbounded && r0 == 0 && c0 == 0:
+		return strings.TrimSuffix(a1, "!")
+	case c1 == Unbounded && c0 == 0 && r1 != Unbounded:
+		return a1 + FormatRow(r0) + ":" + FormatRow(r1-1)
+	case r1 == Unbounded && r0 == 0 && c1 != Unbounded:
+		return a1 + FormatColumn(c0) + ":" + FormatColumn(c1-1)
+	case r1 == r0+1 && c1 == c0+1:
+		return a1 + FormatColumn(c0) + FormatRow(r0)
+	}
+	end := ""
+	switch {
+	case c1 != Unbounded:
+		end += FormatColumn(c1 - 1)
+	case r1 == Unbounded:
+		// A1 can't leave both ends open unless the range starts at A1;
+		// the last column stands in for the edge of the sheet.
+		end += FormatColumn(lastA1Column)
+	}
+	if r1 != Unbounded {
+		end += FormatRow(r1 - 1)
+	}
+	return a1 + FormatColumn(c0) + FormatRow(r0) + ":" + end
+}
+
+// QuoteSheetName quotes a sheet name for use in A1 notation if needed.
+// Names that could be mistaken for a cell reference are quoted too.
+func QuoteSheetName(name string) string {
+	if isPlainSheetName(name) && (name[0] < '0' || name[0] > '9') {
+		if _, _, err := ParseA1(name); err != nil {
+			return name
+		}
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// endIndex returns end, or the largest index if it is Unbounded, so that
+// loops and comparisons over a range don't need to special-case it.
+func endIndex(end int64) int64 {
+	if end == Unbounded {
+		return math.MaxInt64
+	}
+	return end
+}
+
+// apiGridRange returns a copy of gridRange with Unbounded ends left out,
+// as the API expects.
+func apiGridRange(gridRange *sheets.GridRange) *sheets.GridRange {
+	res := *gridRange
+	if res.EndRowIndex == Unbounded {
+		res.EndRowIndex = 0
+	}
+	if res.EndColumnIndex == Unbounded {
+		res.EndColumnIndex = 0
+	}
+	return &res
+}
+
+// gridRangeFromAPI returns a copy of a range received from the API with
+// missing ends made Unbounded.
+func gridRangeFromAPI(gridRange *sheets.GridRange) *sheets.GridRange {
+	res := *gridRange
+	if res.EndRowIndex == 0 {
+		res.EndRowIndex = Unbounded
+	}
+	if res.EndColumnIndex == 0 {
+		res.EndColumnIndex = Unbounded
+	}
+	return &res
+}
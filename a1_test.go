@@ -6,6 +6,12 @@
 // for full license information.
 package ssdb
 
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
 type RangeTestCase struct {
 	Input       string
 	Description string
@@ -234,7 +240,7 @@ var rangeTestCases = []RangeTestCase{
 		UpperLeftRow:  0,
 		UpperLeftCol:  26,
 		LowerRightRow: 1,
-		LowerRightCol: 27,
+		LowerRightCol: 53, // BB, not AB
 	},
 
 	// Invalid cases
@@ -296,11 +302,11 @@ var rangeTestCases = []RangeTestCase{
 	},
 	{
 		Input:         "Sheet1!!A1",
-		Description:   "Double exclamation",
-		UpperLeftRow:  -1,
-		UpperLeftCol:  -1,
-		LowerRightRow: -1,
-		LowerRightCol: -1,
+		Description:   "Double exclamation (sheet \"Sheet1!\")",
+		UpperLeftRow:  0,
+		UpperLeftCol:  0,
+		LowerRightRow: 0,
+		LowerRightCol: 0,
 	},
 	{
 		Input:         "Sheet1!",
@@ -381,9 +387,167 @@ var rangeTestCases = []RangeTestCase{
 	{
 		Input:         "Sheet 1!A1:B2", // Without quotes
 		Description:   "Sheet name with space but no quotes",
-		UpperLeftRow:  -1,
-		UpperLeftCol:  -1,
-		LowerRightRow: -1,
-		LowerRightCol: -1,
+		UpperLeftRow:  0,
+		UpperLeftCol:  0,
+		LowerRightRow: 1,
+		LowerRightCol: 1,
 	},
 }
+
+func TestParseA1(t *testing.T) {
+	for _, tc := range rangeTestCases {
+		row0, col0, row1, col1 := int64(-1), int64(-1), int64(-1), int64(-1)
+		_, gridRange, err := ParseA1(tc.Input)
+		if err == nil {
+			// ends are exclusive; an Unbounded end comes out as -2
+			row0, col0 = gridRange.StartRowIndex, gridRange.StartColumnIndex
+			row1, col1 = gridRange.EndRowIndex-1, gridRange.EndColumnIndex-1
+		}
+		assert.Equal(t, int64(tc.UpperLeftRow), row0, tc.Description)
+		assert.Equal(t, int64(tc.UpperLeftCol), col0, tc.Description)
+		assert.Equal(t, int64(tc.LowerRightRow), row1, tc.Description)
+		assert.Equal(t, int64(tc.LowerRightCol), col1, tc.Description)
+	}
+}
+
+func TestFormatA1(t *testing.T) {
+	for _, tc := range rangeTestCases {
+		sheetName, gridRange, err := ParseA1(tc.Input)
+		if err != nil {
+			continue
+		}
+		_, again, err := ParseA1(FormatA1(sheetName, gridRange))
+		assert.NoError(t, err, tc.Description)
+		assert.Equal(t, gridRange, again, tc.Description)
+	}
+}
+
+func TestParseA1SheetName(t *testing.T) {
+	tbl := []struct {
+		a1        string
+		sheetName string
+		quoted    string // as FormatA1 writes it
+	}{
+		{a1: "Sheet1!A1", sheetName: "Sheet1", quoted: "Sheet1!A1"},
+		{a1: "My Sheet!A1", sheetName: "My Sheet", quoted: "'My Sheet'!A1"},
+		{a1: "Données!A1", sheetName: "Données", quoted: "'Données'!A1"},
+		{a1: "Members-2025!B2:C3", sheetName: "Members-2025", quoted: "'Members-2025'!B2:C3"},
+		{a1: "'It''s'!A1", sheetName: "It's", quoted: "'It''s'!A1"},
+	}
+	for _, tc := range tbl {
+		sheetName, gridRange, err := ParseA1(tc.a1)
+		assert.NoError(t, err, tc.a1)
+		assert.Equal(t, tc.sheetName, sheetName, tc.a1)
+		assert.Equal(t, tc.quoted, FormatA1(sheetName, gridRange), tc.a1)
+	}
+	_, _, err := ParseA1("!A1")
+	assert.ErrorIs(t, err, ErrBadA1)
+}
+
+func TestParseR1C1(t *testing.T) {
+	tbl := []struct {
+		r1c1 string
+		a1   string
+	}{
+		{r1c1: "R1C1", a1: "A1"},
+		{r1c1: "r6c2:R10C10", a1: "B6:J10"},
+		{r1c1: "R10C10:R6C2", a1: "B6:J10"},
+		{r1c1: "R5:R10", a1: "5:10"},
+		{r1c1: "C2:C5", a1: "B:E"},
+		{r1c1: "R1C1:C1", a1: "A1:A"},
+		{r1c1: "'My Sheet'!R1C703", a1: "'My Sheet'!AAA1"},
+		// relative to an anchor at C3 (row 2, col 2)
+		{r1c1: "RC", a1: "C3"},
+		{r1c1: "R[-1]C[2]", a1: "E2"},
+		{r1c1: "R[-2]C:R[1]C[1]", a1: "C1:D4"},
+		{r1c1: "R2C[-2]", a1: "A2"},
+	}
+	for _, tc := range tbl {
+		sheetName, gridRange, err := ParseR1C1(tc.r1c1, 2, 2)
+		assert.NoError(t, err, tc.r1c1)
+		sheetA1, gridA1, _ := ParseA1(tc.a1)
+		assert.Equal(t, sheetA1, sheetName, tc.r1c1)
+		assert.Equal(t, gridA1, gridRange, tc.r1c1)
+
+		_, again, err := ParseR1C1(FormatR1C1(sheetName, gridRange), 0, 0)
+		assert.NoError(t, err, tc.r1c1)
+		assert.Equal(t, gridRange, again, tc.r1c1)
+		_, again, err = ParseR1C1(FormatR1C1Relative(sheetName, gridRange, 4, 4), 4, 4)
+		assert.NoError(t, err, tc.r1c1)
+		assert.Equal(t, gridRange, again, tc.r1c1)
+	}
+	for _, bad := range []string{"", "R0C1", "R[-3]C", "R1C1:R2C2:R3C3", "R1X", "R[1C1", "A1"} {
+		_, _, err := ParseR1C1(bad, 2, 2)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseSymbolicRangeCorners(t *testing.T) {
+	tbl := []struct {
+		in     string
+		corner bool // false is NW, true is SE (exclusive)
+		row    int64
+		col    int64
+	}{
+		{in: "b7", corner: false, row: 6, col: 1},
+		{in: "b7", corner: true, row: 7, col: 2},
+		{in: "b7:b9", corner: false, row: 6, col: 1},
+		{in: "b7:b9", corner: true, row: 9, col: 2},
+		{in: "b7:c9", corner: false, row: 6, col: 1},
+		{in: "b7:c9", corner: true, row: 9, col: 3},
+		{in: "b7:c", corner: false, row: 6, col: 1},
+		{in: "b7:c", corner: true, row: Unbounded, col: 3},
+		{in: "b7:", corner: false, row: -1, col: -1},
+		{in: "b7:", corner: true, row: -1, col: -1},
+	}
+	for _, testcase := range tbl {
+		rowRes, colRes := ParseSymbolicRange(testcase.corner, testcase.in)
+		assert.Equal(t, testcase.row, rowRes, testcase.in)
+		assert.Equal(t, testcase.col, colRes, testcase.in)
+	}
+}
+
+func TestParseSymbolicCellCorners(t *testing.T) {
+	tbl := []struct {
+		in     string
+		corner bool // false is NW, true is SE (exclusive)
+		row    int64
+		col    int64
+	}{
+		{in: "b7", corner: false, row: 6, col: 1},
+		{in: "b7", corner: true, row: 7, col: 2},
+		{in: "b", corner: false, row: 0, col: 1},
+		{in: "b", corner: true, row: Unbounded, col: 2},
+		{in: "7", corner: false, row: 6, col: 0},
+		{in: "7", corner: true, row: 7, col: Unbounded},
+	}
+	for _, testcase := range tbl {
+		rowRes, colRes := ParseSymbolicCell(testcase.corner, testcase.in)
+		assert.Equal(t, testcase.row, rowRes, testcase.in)
+		assert.Equal(t, testcase.col, colRes, testcase.in)
+	}
+}
+
+func TestParseColumn(t *testing.T) {
+	tbl := []struct {
+		in  string
+		out int64
+	}{
+		{in: "A", out: 0},
+		{in: "B", out: 1},
+		{in: "AA", out: 26},
+		{in: "AB", out: 27},
+		{in: "BA", out: 52},
+		{in: "ZZ", out: 701},
+		{in: "AAA", out: 702},
+		{in: "", out: -1},
+		{in: "A1", out: -1},
+	}
+	for _, testcase := range tbl {
+		res := ParseColumn(testcase.in)
+		assert.Equal(t, testcase.out, res, testcase.in)
+		if res >= 0 {
+			assert.Equal(t, testcase.in, FormatColumn(res))
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var ErrCellBlank = errors.New("cell is blank")
+var ErrCellType = errors.New("cell has the wrong type")
+
+// The typed getters below read the cell's EffectiveValue (what the sheet
+// computed, after formulas) when there is one and fall back to parsing the
+// displayed text. Errors wrap ErrCellBlank or ErrCellType and name the
+// cell, e.g. `Members!D7: "n/a" is not a number`.
+
+// Address returns the A1 address of the cell, e.g. "Members!D7".
+func (cell *Cell) Address() string {
+	if cell == nil || cell.Sheet == nil || cell.Row == nil {
+		return "?"
+	}
+	return FormatA1(cell.Sheet.Sheet.Properties.Title, cell.Range().gridRange)
+}
+
+func (cell *Cell) effective() *sheets.ExtendedValue {
+	if cell.Cell.EffectiveValue != nil {
+		return cell.Cell.EffectiveValue
+	}
+	return cell.Cell.UserEnteredValue
+}
+
+// typedText returns the cell's text for parsing, or an error if it's blank.
+func (cell *Cell) typedText() (text string, err error) {
+	if cell == nil || cell.Cell == nil {
+		return "", ErrCellBlank
+	}
+	text = strings.TrimSpace(GetCellDataString(cell.Cell))
+	if text == "" {
+		return "", fmt.Errorf("%s: %w", cell.Address(), ErrCellBlank)
+	}
+	return text, nil
+}
+
+func (cell *Cell) typeError(text, want string) error {
+	return fmt.Errorf("%s: %q is not %s: %w", cell.Address(), text, want, ErrCellType)
+}
+
+// GetFloat returns the cell as a number.
+func (cell *Cell) GetFloat() (val float64, err error) {
+	text, err := cell.typedText()
+	if err != nil {
+		return 0, err
+	}
+	if ev := cell.effective(); ev != nil && ev.NumberValue != nil {
+		return *ev.NumberValue, nil
+	}
+	val, _, ok := cell.DB.Locale().ParseNumber(text)
+	if !ok {
+		return 0, cell.typeError(text, "a number")
+	}
+	return val, nil
+}
+
+// GetInt returns the cell as a whole number.
+func (cell *Cell) GetInt() (val int64, err error) {
+	f, err := cell.GetFloat()
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, cell.typeError(GetCellDataString(cell.Cell), "a whole number")
+	}
+	return int64(f), nil
+}
+
+// GetDecimal returns the cell as a plain decimal string ("1250.50",
+// "-3.5") without going through float formatting, so no digits are
+// invented or lost. The displayed digits are used when they agree with
+// the stored number, which keeps trailing zeros from number formats.
+func (cell *Cell) GetDecimal() (val string, err error) {
+	text, err := cell.typedText()
+	if err != nil {
+		return "", err
+	}
+	shown, _, shownOK := cell.DB.Locale().ParseDecimal(text)
+	ev := cell.effective()
+	switch {
+	case ev != nil && ev.NumberValue != nil:
+		if f, err := strconv.ParseFloat(shown, 64); shownOK && err == nil && f == *ev.NumberValue {
+			return shown, nil
+		}
+		return strconv.FormatFloat(*ev.NumberValue, 'f', -1, 64), nil
+	case shownOK:
+		return shown, nil
+	}
+	return "", cell.typeError(text, "a decimal number")
+}
+
+// GetBool returns the cell as a boolean. Besides checkbox values it accepts
+// true/false, yes/no, y/n, on/off and 1/0 in any case.
+func (cell *Cell) GetBool() (val bool, err error) {
+	text, err := cell.typedText()
+	if err != nil {
+		return false, err
+	}
+	if ev := cell.effective(); ev != nil && ev.BoolValue != nil {
+		return *ev.BoolValue, nil
+	}
+	switch strings.ToLower(text) {
+	case "true", "yes", "y", "on", "1":
+		return true, nil
+	case "false", "no", "n", "off", "0":
+		return false, nil
+	}
+	return false, cell.typeError(text, "a boolean")
+}
+
+// GetTime returns the cell as a time in the spreadsheet's time zone. Date
+// cells hold serial day numbers; text is read in the spreadsheet's date
+// order or as ISO 8601 (see Locale.ParseTime).
+func (cell *Cell) GetTime() (val time.Time, err error) {
+	text, err := cell.typedText()
+	if err != nil {
+		return time.Time{}, err
+	}
+	locale := cell.DB.Locale()
+	if ev := cell.effective(); ev != nil && ev.NumberValue != nil {
+		return SerialToTime(*ev.NumberValue, locale.Location), nil
+	}
+	val, ok := locale.ParseTime(text)
+	if !ok {
+		return time.Time{}, cell.typeError(text, "a date")
+	}
+	return val, nil
+}
+
+// GetDuration returns the cell as a duration. Duration cells hold a number
+// of days; text may be [h]:mm[:ss] or a Go duration such as "1h30m".
+func (cell *Cell) GetDuration() (val time.Duration, err error) {
+	text, err := cell.typedText()
+	if err != nil {
+		return 0, err
+	}
+	if ev := cell.effective(); ev != nil && ev.NumberValue != nil {
+		return time.Duration(math.Round(*ev.NumberValue * float64(24*time.Hour))), nil
+	}
+	val, ok := parseDuration(text)
+	if !ok {
+		return 0, cell.typeError(text, "a duration")
+	}
+	return val, nil
+}
+
+func parseDuration(text string) (val time.Duration, ok bool) {
+	if d, err := time.ParseDuration(text); err == nil {
+		return d, true
+	}
+	neg := strings.HasPrefix(text, "-")
+	spl := strings.Split(strings.TrimPrefix(text, "-"), ":")
+	if len(spl) < 2 || len(spl) > 3 {
+		return 0, false
+	}
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, part := range spl {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		val += time.Duration(n * float64(units[i]))
+	}
+	if neg {
+		val = -val
+	}
+	return val, true
+}
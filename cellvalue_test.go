@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func testCell(formatted string, ev *sheets.ExtendedValue) *Cell {
+	db := &SSDB{spreadsheet: &sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{TimeZone: "America/New_York"},
+	}}
+	sheet := &Sheet{DB: db, Sheet: &sheets.Sheet{Properties: &sheets.SheetProperties{Title: "Members"}}}
+	return &Cell{
+		N:     3,
+		DB:    db,
+		Sheet: sheet,
+		Row:   &Row{N: 6, DB: db, Sheet: sheet},
+		Cell:  &sheets.CellData{FormattedValue: formatted, EffectiveValue: ev},
+	}
+}
+
+func num(f float64) *sheets.ExtendedValue {
+	return &sheets.ExtendedValue{NumberValue: &f}
+}
+
+func TestTypedCells(t *testing.T) {
+	i, err := testCell("1,250", num(1250)).GetInt()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1250), i)
+
+	_, err = testCell("2.5", num(2.5)).GetInt()
+	assert.ErrorIs(t, err, ErrCellType)
+
+	_, err = testCell("n/a", nil).GetFloat()
+	assert.ErrorIs(t, err, ErrCellType)
+	assert.EqualError(t, err, `Members!D7: "n/a" is not a number: cell has the wrong type`)
+
+	_, err = testCell("", nil).GetFloat()
+	assert.ErrorIs(t, err, ErrCellBlank)
+
+	d, err := testCell("$1,250.50", num(1250.5)).GetDecimal()
+	assert.NoError(t, err)
+	assert.Equal(t, "1250.50", d)
+	d, err = testCell("1.3E+03", num(1250.5)).GetDecimal()
+	assert.NoError(t, err)
+	assert.Equal(t, "1250.5", d)
+
+	b, err := testCell("Yes", nil).GetBool()
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	loc, _ := time.LoadLocation("America/New_York")
+	tm, err := testCell("7/4/2025", num(45842.5)).GetTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 4, 12, 0, 0, 0, loc), tm)
+	tm, err = testCell("2025-07-04", nil).GetTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 4, 0, 0, 0, 0, loc), tm)
+
+	dur, err := testCell("1:30:00", num(0.0625)).GetDuration()
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, dur)
+	dur, err = testCell("2:15", nil).GetDuration()
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour+15*time.Minute, dur)
+}
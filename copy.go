@@ -8,7 +8,7 @@ package ssdb
 
 func (sheet *Sheet) CompareVals(dbrange *DBRange, vals [][]any) (res bool) {
 	row0, col0, row1, col1 := dbrange.gridRange.StartRowIndex, dbrange.gridRange.StartColumnIndex,
-		dbrange.gridRange.EndRowIndex, dbrange.gridRange.EndColumnIndex
+		endIndex(dbrange.gridRange.EndRowIndex), endIndex(dbrange.gridRange.EndColumnIndex)
 	_, _, _, _ = row0, col0, row1, col1
 
 	res = true
@@ -31,7 +31,7 @@ func (sheet *Sheet) CompareVals(dbrange *DBRange, vals [][]any) (res bool) {
 
 func (sheet *Sheet) CopyVals(dbrange *DBRange) (vals [][]any) {
 	row0, col0, row1, col1 := dbrange.gridRange.StartRowIndex, dbrange.gridRange.StartColumnIndex,
-		dbrange.gridRange.EndRowIndex, dbrange.gridRange.EndColumnIndex
+		endIndex(dbrange.gridRange.EndRowIndex), endIndex(dbrange.gridRange.EndColumnIndex)
 	_, _, _, _ = row0, col0, row1, col1
 
 	sheet.RowIter(func(row *Row) {
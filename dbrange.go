@@ -7,37 +7,42 @@
 package ssdb
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 	"strings"
 
 	"google.golang.org/api/sheets/v4"
 )
 
+var ErrBadRange = errors.New("bad range")
+
 type DBRange struct {
 	symbolicRange string
 	gridRange     *sheets.GridRange
 	sheet         *Sheet
 }
 
+// Extend returns the smallest range covering both dbrange and datum.
+// Neither input is modified.
 func (dbrange *DBRange) Extend(datum *DBRange) (res *DBRange) {
-	foo := *dbrange
-	res = &foo
-	if datum.gridRange.StartColumnIndex < dbrange.gridRange.StartColumnIndex {
-		res.gridRange.StartColumnIndex = datum.gridRange.StartColumnIndex
+	gridRange := *dbrange.gridRange
+	if datum.gridRange.StartColumnIndex < gridRange.StartColumnIndex {
+		gridRange.StartColumnIndex = datum.gridRange.StartColumnIndex
 	}
-	if datum.gridRange.StartRowIndex < dbrange.gridRange.StartRowIndex {
-		res.gridRange.StartRowIndex = datum.gridRange.StartRowIndex
+	if datum.gridRange.StartRowIndex < gridRange.StartRowIndex {
+		gridRange.StartRowIndex = datum.gridRange.StartRowIndex
 	}
-	if datum.gridRange.EndColumnIndex > dbrange.gridRange.EndColumnIndex {
-		res.gridRange.EndColumnIndex = datum.gridRange.EndColumnIndex
+	if endIndex(datum.gridRange.EndColumnIndex) > endIndex(gridRange.EndColumnIndex) {
+		gridRange.EndColumnIndex = datum.gridRange.EndColumnIndex
 	}
-	if datum.gridRange.EndRowIndex > dbrange.gridRange.EndRowIndex {
-		res.gridRange.EndRowIndex = datum.gridRange.EndRowIndex
+	if endIndex(datum.gridRange.EndRowIndex) > endIndex(gridRange.EndRowIndex) {
+		gridRange.EndRowIndex = datum.gridRange.EndRowIndex
 	}
-	return //
+	return dbrange.derive(&gridRange)
 }
 
+// NeedsGrowth reports how far the sheet (dbrange being its extents) must
+// grow to hold datum. Unbounded ends never ask for growth.
 func (dbrange *DBRange) NeedsGrowth(datum *DBRange) (growRows, growColumns int64) {
 	if datum.gridRange.EndRowIndex > dbrange.gridRange.EndRowIndex {
 		growRows = datum.gridRange.EndRowIndex - dbrange.gridRange.EndRowIndex
@@ -49,22 +54,10 @@ func (dbrange *DBRange) NeedsGrowth(datum *DBRange) (growRows, growColumns int64
 }
 
 func (dbrange *DBRange) String() (s string) {
-	s += dbrange.sheet.Sheet.Properties.Title + "!"
-	rowMin, colMin, rowMax, colMax :=
-		dbrange.gridRange.StartRowIndex,
-		dbrange.gridRange.StartColumnIndex,
-		dbrange.gridRange.EndRowIndex,
-		dbrange.gridRange.EndColumnIndex
-
-	s += FormatNumericColumn(false, colMin)
-	s += FormatNumericRow(false, rowMin)
-
-	swrow := FormatNumericRow(true, rowMax)
-	swcol := FormatNumericColumn(true, colMax)
-	if len(swrow+swcol) > 0 {
-		s += ":" + swcol + swrow
+	if dbrange.sheet == nil {
+		return dbrange.symbolicRange // unmarshalled, not yet resolved
 	}
-	return //
+	return FormatA1(dbrange.sheet.Sheet.Properties.Title, dbrange.gridRange)
 }
 
 func (cell *Cell) Range() (dbRange *DBRange) {
@@ -91,6 +84,12 @@ func (ssdb *SSDB) NewDBRange(sheetname string, row, col, rows, cols int64) (dbRa
 	if sheet == nil {
 		return nil
 	}
+	return sheet.NewDBRange(row, col, rows, cols)
+}
+
+// NewDBRange returns the range of rows by cols cells of sheet whose top
+// left cell is at row, col (zero based).
+func (sheet *Sheet) NewDBRange(row, col, rows, cols int64) (dbRange *DBRange) {
 	gridRange := &sheets.GridRange{
 		SheetId:          sheet.GetID(),
 		StartColumnIndex: col,
@@ -122,37 +121,21 @@ func (ssdb *SSDB) SheetLookup(sheetMatch string) (foundSheet *Sheet) {
 	return //
 }
 
+// NewDBRangeFromSymbolicRange accepts either Sheet!A1 notation (see
+// ParseA1) or the name of a named range defined in the spreadsheet.
 func (ssdb *SSDB) NewDBRangeFromSymbolicRange(symbolicRange string) (dbRange *DBRange) {
-	spl := strings.Split(symbolicRange, "!")
-	if len(spl) < 2 {
-		return nil
+	if !strings.Contains(symbolicRange, "!") {
+		return ssdb.NamedRangeLookup(symbolicRange)
 	}
-	sheetMatch := spl[0]
-	row0, col0 := ParseSymbolicRange(false, spl[1])
-	row1, col1 := ParseSymbolicRange(true, spl[1])
-	if row0 == -1 || col0 == -1 || row1 == -1 || col1 == -1 {
+	sheetMatch, gridRange, err := ParseA1(symbolicRange)
+	if err != nil {
 		return nil
 	}
-	gridRange := &sheets.GridRange{
-		StartColumnIndex: col0,
-		EndColumnIndex:   col1,
-		StartRowIndex:    row0,
-		EndRowIndex:      row1,
-	}
-	var foundSheet *Sheet
-	err := false
-	ssdb.SheetIter(func(sheetname string, sheet *Sheet) {
-		switch {
-		case foundSheet != nil && sheetname == sheetMatch:
-			err = true
-		case sheetname == sheetMatch:
-			foundSheet = sheet
-			gridRange.SheetId = sheet.GetID()
-		}
-	})
-	if err || foundSheet == nil {
+	foundSheet := ssdb.SheetLookup(sheetMatch)
+	if foundSheet == nil {
 		return nil
 	}
+	gridRange.SheetId = foundSheet.GetID()
 	dbRange = &DBRange{
 		symbolicRange: symbolicRange,
 		gridRange:     gridRange,
@@ -161,131 +144,70 @@ func (ssdb *SSDB) NewDBRangeFromSymbolicRange(symbolicRange string) (dbRange *DB
 	return //
 }
 
+// Deprecated: use ParseA1.
 func ParseSymbolicRangeNW(cellName string) (row, col int64) {
 	return ParseSymbolicRange(false, cellName)
 }
 
+// Deprecated: use ParseA1.
 func ParseSymbolicRangeSE(cellName string) (row, col int64) {
 	return ParseSymbolicRange(true, cellName)
 }
 
-// corner: false UL, true SE
+// ParseSymbolicRange returns the NW corner (corner false) or the exclusive
+// SE corner (corner true) of an A1 range, or -1, -1 if it doesn't parse.
+//
+// Deprecated: use ParseA1.
 func ParseSymbolicRange(corner bool, cellName string) (row, col int64) {
-	spl := strings.Split(cellName, ":")
+	_, gridRange, err := ParseA1(cellName)
 	switch {
-	case len(spl) == 0:
+	case err != nil:
 		return -1, -1
-	case len(spl) == 1:
-		return ParseSymbolicCell(corner, cellName)
-	case !corner && len(spl) == 2:
-		return ParseSymbolicCell(corner, spl[0])
-	case corner && len(spl) == 2:
-		return ParseSymbolicCell(corner, spl[1])
+	case corner:
+		return gridRange.EndRowIndex, gridRange.EndColumnIndex
+	default:
+		return gridRange.StartRowIndex, gridRange.StartColumnIndex
 	}
-	return //
 }
 
-// corner: false UL, true SE
+// ParseSymbolicCell is ParseSymbolicRange for one side of a range, which
+// may be a bare row or column.
+//
+// Deprecated: use ParseA1.
 func ParseSymbolicCell(corner bool, cellName string) (row, col int64) {
-	alpha := ""
-	numeric := ""
-	for _, c := range cellName {
-		switch {
-		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') && len(numeric) == 0:
-			alpha += string(c)
-		case c >= '0' && c <= '9':
-			numeric += string(c)
-		default:
-			return -1, -1
-		}
+	ref, err := parseA1Ref(cellName)
+	if err != nil {
+		return -1, -1
 	}
-	switch {
-	case !corner && len(numeric) == 0 && len(alpha) == 0:
-		return 0, 0
-	case !corner && len(numeric) == 0:
-		return 0, ParseSymbolicColumn(alpha)
-	case !corner && len(alpha) == 0:
-		return ParseSymbolicRow(numeric), 0
-	case corner && len(numeric) == 0 && len(alpha) == 0:
-		return 9999, 9999
-	case corner && len(numeric) == 0:
-		return 9999, ParseSymbolicColumn(alpha) + 1
-	case corner && len(alpha) == 0:
-		return ParseSymbolicRow(numeric) + 1, 9999
-	case corner:
-		return ParseSymbolicRow(numeric) + 1, ParseSymbolicColumn(alpha) + 1
-	default:
-		return ParseSymbolicRow(numeric), ParseSymbolicColumn(alpha)
+	if !corner {
+		return ref.row, ref.col // a missing row or column is zero
 	}
+	_, row = a1Span(ref.row, ref.hasRow, ref.row, ref.hasRow)
+	_, col = a1Span(ref.col, ref.hasCol, ref.col, ref.hasCol)
+	return //
 }
 
-// Accepts input A, B, C, ..., Z, AA, AB, ... AZ, ..., ZZ
+// Deprecated: use ParseRow.
 func ParseSymbolicRow(rowNumStr string) (row int64) {
-	if len(rowNumStr) == 0 || len(rowNumStr) > 5 {
-		return -1
-	}
-	for _, c := range rowNumStr {
-		switch {
-		case c >= 48 && c < 58:
-			row = row*10 + int64(c-48)
-		default:
-			return -1
-		}
-	}
-	row--  // make zero based
-	return //
+	return ParseRow(rowNumStr)
 }
 
-// Accepts input A, B, C, ..., Z, AA, AB, ... AZ, ..., ZZ
+// Deprecated: use ParseColumn.
 func ParseSymbolicColumn(colName string) (col int64) {
-	colName = strings.ToUpper(colName)
-	switch {
-	case len(colName) == 1:
-		col = int64([]byte(colName)[0] - 65)
-	case len(colName) == 2:
-		col = int64(([]byte(colName)[0]-65)*26+[]byte(colName)[1]-65) + 26
-	default:
-		col = -1
-	}
-	return //
+	return ParseColumn(colName)
 }
 
-// Accepts input A, B, C, ..., Z, AA, AB, ... AZ, ..., ZZ
+// FormatNumericRow formats a row index as is; an Unbounded SE corner is "".
+//
+// Deprecated: use FormatRow, which also makes the number one based.
 func FormatNumericRow(corner bool, rownum int64) (rowName string) {
-	switch {
-	case rownum < 0:
-		panic("Out of range")
-	case rownum < 9999:
-		rowName = strconv.FormatInt(rownum, 10)
-	case !corner && rownum == 9999:
-		rowName = strconv.FormatInt(rownum, 10)
-	case corner && rownum == 9999:
-		rowName = ""
-	default:
-		rowName = "OUTOFRANGE"
-		panic("colName out of range")
+	if corner && rownum == Unbounded {
+		return ""
 	}
-	return //
+	return strconv.FormatInt(rownum, 10)
 }
 
-// Accepts input A, B, C, ..., Z, AA, AB, ... AZ, ..., ZZ
+// Deprecated: use FormatColumn.
 func FormatNumericColumn(corner bool, colnum int64) (colName string) {
-	switch {
-	case colnum < 26:
-		// colName = string('A' + colnum)
-		colName = fmt.Sprintf("%c", colnum+65)
-	case colnum < 26*26:
-		// colName = string('A'+colnum/26) + string('A'+colnum%26)
-		colName = fmt.Sprintf("%c%c", colnum/26+65, colnum%26+65)
-	case corner && colnum == 9999:
-		colName = ""
-
-	// error conditions:
-	case !corner && colnum == 9999:
-		fallthrough
-	default:
-		colName = "OUTOFRANGE"
-		panic("colName out of range")
-	}
-	return //
+	return FormatColumn(colnum)
 }
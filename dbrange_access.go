@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Sheet returns the sheet dbrange is on, or nil for a range that was
+// unmarshalled and not yet resolved (see SSDB.Resolve).
+func (dbrange *DBRange) Sheet() *Sheet {
+	return dbrange.sheet
+}
+
+// SheetName returns the title of the sheet dbrange is on.
+func (dbrange *DBRange) SheetName() string {
+	if dbrange.sheet != nil {
+		return dbrange.sheet.Sheet.Properties.Title
+	}
+	sheetName, _, _ := ParseA1(dbrange.symbolicRange)
+	return sheetName
+}
+
+// SheetID returns the ID of the sheet dbrange is on.
+func (dbrange *DBRange) SheetID() int64 {
+	if dbrange.sheet != nil {
+		return dbrange.sheet.GetID()
+	}
+	return dbrange.GridRange().SheetId
+}
+
+// StartRow returns the zero based first row of dbrange.
+func (dbrange *DBRange) StartRow() int64 {
+	return dbrange.GridRange().StartRowIndex
+}
+
+// EndRow returns the zero based row just past dbrange, or Unbounded.
+func (dbrange *DBRange) EndRow() int64 {
+	return dbrange.GridRange().EndRowIndex
+}
+
+// StartColumn returns the zero based first column of dbrange.
+func (dbrange *DBRange) StartColumn() int64 {
+	return dbrange.GridRange().StartColumnIndex
+}
+
+// EndColumn returns the zero based column just past dbrange, or Unbounded.
+func (dbrange *DBRange) EndColumn() int64 {
+	return dbrange.GridRange().EndColumnIndex
+}
+
+// GridRange returns a copy of the coordinates of dbrange. Unbounded ends
+// are Unbounded, not the API's zero. An unresolved named range has no
+// coordinates yet and returns an empty range.
+func (dbrange *DBRange) GridRange() *sheets.GridRange {
+	if dbrange.gridRange == nil {
+		return &sheets.GridRange{}
+	}
+	gridRange := *dbrange.gridRange
+	return &gridRange
+}
+
+// MarshalText encodes dbrange in A1 notation (or as the name of a named
+// range that hasn't been resolved).
+func (dbrange *DBRange) MarshalText() ([]byte, error) {
+	return []byte(dbrange.String()), nil
+}
+
+// MarshalJSON encodes dbrange as an A1 string.
+func (dbrange *DBRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dbrange.String())
+}
+
+// UnmarshalText decodes Sheet!A1 notation or a named range name. The sheet
+// (or named range) can't be looked up without a spreadsheet, so the result
+// must be passed through SSDB.Resolve before it is used for I/O.
+func (dbrange *DBRange) UnmarshalText(text []byte) (err error) {
+	s := string(text)
+	res := DBRange{symbolicRange: s}
+	if strings.Contains(s, "!") {
+		_, res.gridRange, err = ParseA1(s)
+		if err != nil {
+			return fmt.Errorf("%q: %w", s, err)
+		}
+	}
+	*dbrange = res
+	return nil
+}
+
+// Resolve binds an unmarshalled range to this spreadsheet. Ranges that
+// are already bound are re-resolved by name, which also picks up a named
+// range that has moved.
+func (db *SSDB) Resolve(dbrange *DBRange) (res *DBRange, err error) {
+	res = db.NewDBRangeFromSymbolicRange(dbrange.symbolicRange)
+	if res == nil {
+		return nil, fmt.Errorf("%q: %w", dbrange.symbolicRange, ErrBadRange)
+	}
+	return res, nil
+}
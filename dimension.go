@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"slices"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// DeleteRows removes n rows starting at row N (zero based) from sheet; the
+// rows below move up. Deletions run after the batch's value updates, so
+// those are still addressed by the old row numbers. So are further
+// deletions in the same batch: N counts the rows as they are in the cache,
+// and rows already deleted in the batch are not deleted again.
+func (upd *Updater) DeleteRows(sheet *Sheet, N, n int64) {
+	N, n = upd.queuedRows(sheet.GetID(), N, n)
+	if n <= 0 {
+		return
+	}
+	upd.Lock()
+	upd.reshaped = true
+	upd.Unlock()
+	upd.queueRequest(&sheets.Request{
+		DeleteDimension: &sheets.DeleteDimensionRequest{
+			Range: &sheets.DimensionRange{
+				SheetId:    sheet.GetID(),
+				Dimension:  "ROWS",
+				StartIndex: N,
+				EndIndex:   N + n,
+			},
+		},
+	}, func(reply *sheets.Response) {
+		if len(sheet.Sheet.Data) == 0 {
+			return
+		}
+		gridData := sheet.Sheet.Data[0]
+		gridData.RowData = deleteRange(gridData.RowData, N, n)
+		gridData.RowMetadata = deleteRange(gridData.RowMetadata, N, n)
+		if props := sheet.Sheet.Properties; props != nil && props.GridProperties != nil {
+			props.GridProperties.RowCount -= min(n, max(props.GridProperties.RowCount-N, 0))
+		}
+	})
+}
+
+// queuedRows maps n rows from row N of the cache to where they will be once
+// the row deletions already queued for the sheet have run. Rows those
+// delete are dropped, so fewer than n may be left.
+func (upd *Updater) queuedRows(sheetID, N, n int64) (int64, int64) {
+	upd.Lock()
+	defer upd.Unlock()
+
+	for _, item := range upd.requestQueue {
+		del := item.request.DeleteDimension
+		if del == nil || del.Range.SheetId != sheetID || del.Range.Dimension != "ROWS" {
+			continue
+		}
+		overlap := func(start, end int64) int64 {
+			return max(0, min(end, del.Range.EndIndex)-max(start, del.Range.StartIndex))
+		}
+		N, n = N-overlap(0, N), n-overlap(N, N+n)
+	}
+	return N, n
+}
+
+func deleteRange[E any](s []E, N, n int64) []E {
+	if N >= int64(len(s)) {
+		return s
+	}
+	return append(s[:N], s[min(N+n, int64(len(s))):]...)
+}
+
+// MoveColumns moves n columns starting at column N (zero based) of sheet so
+// that they start at column dest, counted before the move; data, formats
+// and notes go with them. Moves run after the batch's value updates.
+func (upd *Updater) MoveColumns(sheet *Sheet, N, n, dest int64) {
+	upd.Lock()
+	upd.reshaped = true
+	upd.Unlock()
+	upd.queueRequest(&sheets.Request{
+		MoveDimension: &sheets.MoveDimensionRequest{
+			Source: &sheets.DimensionRange{
+				SheetId:    sheet.GetID(),
+				Dimension:  "COLUMNS",
+				StartIndex: N,
+				EndIndex:   N + n,
+			},
+			DestinationIndex: dest,
+		},
+	}, func(reply *sheets.Response) {
+		if len(sheet.Sheet.Data) == 0 {
+			return
+		}
+		gridData := sheet.Sheet.Data[0]
+		for _, row := range gridData.RowData {
+			if row != nil {
+				row.Values = moveRange(row.Values, N, n, dest)
+			}
+		}
+		gridData.ColumnMetadata = moveRange(gridData.ColumnMetadata, N, n, dest)
+	})
+}
+
+// moveRange is the cache side of MoveDimension. Short slices are padded
+// with zero values as needed.
+func moveRange[E any](s []E, N, n, dest int64) []E {
+	if dest >= N && dest <= N+n {
+		return s // already in place
+	}
+	need := max(N+n, dest)
+	if int64(len(s)) < need {
+		if int64(len(s)) <= N && int64(len(s)) <= dest {
+			return s // nothing cached on either side
+		}
+		s = append(s, make([]E, need-int64(len(s)))...)
+	}
+	moved := slices.Clone(s[N : N+n])
+	s = slices.Delete(s, int(N), int(N+n))
+	if dest > N {
+		dest -= n
+	}
+	return slices.Insert(s, int(dest), moved...)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestDeleteRows(t *testing.T) {
+	sheet := testRange(t, "A1").sheet
+	for _, text := range []string{"a", "b", "c", "d"} {
+		sheet.Sheet.Data[0].RowData = append(sheet.Sheet.Data[0].RowData, &sheets.RowData{
+			Values: []*sheets.CellData{{FormattedValue: text}},
+		})
+	}
+	upd := &Updater{}
+	upd.DeleteRows(sheet, 1, 2)
+	assert.True(t, upd.reshaped)
+	assert.Equal(t, int64(1), upd.requestQueue[0].request.DeleteDimension.Range.StartIndex)
+	upd.requestQueue[0].apply(nil)
+	assert.Len(t, sheet.Sheet.Data[0].RowData, 2)
+	assert.Equal(t, "d", sheet.GetRowN(1).GetCellN(0).GetString())
+
+	upd.DeleteRows(sheet, 1, 5) // past the cached rows
+	upd.requestQueue[1].apply(nil)
+	assert.Len(t, sheet.Sheet.Data[0].RowData, 1)
+}
+
+func TestDeleteRowsBatch(t *testing.T) {
+	sheet := testRange(t, "A1").sheet
+	for _, text := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		sheet.Sheet.Data[0].RowData = append(sheet.Sheet.Data[0].RowData, &sheets.RowData{
+			Values: []*sheets.CellData{{FormattedValue: text}},
+		})
+	}
+	upd := &Updater{}
+	// all by the cached row numbers
+	upd.DeleteRows(sheet, 1, 1) // b
+	upd.DeleteRows(sheet, 3, 1) // d
+	upd.DeleteRows(sheet, 3, 1) // d again
+	upd.DeleteRows(sheet, 0, 3) // a, and c; b is gone
+	upd.DeleteRows(sheet, 5, 1) // f
+	var starts []int64
+	for _, item := range upd.requestQueue {
+		r := item.request.DeleteDimension.Range
+		starts = append(starts, r.StartIndex, r.EndIndex-r.StartIndex)
+		item.apply(nil)
+	}
+	assert.Equal(t, []int64{1, 1, 2, 1, 0, 2, 1, 1}, starts)
+	var left string
+	sheet.RowIter(func(row *Row) {
+		left += row.GetCellN(0).GetString()
+	})
+	assert.Equal(t, "eg", left)
+}
+
+func TestMoveColumns(t *testing.T) {
+	sheet := testRange(t, "A1").sheet
+	sheet.Sheet.Data[0].RowData = []*sheets.RowData{{Values: []*sheets.CellData{
+		{FormattedValue: "a"}, {FormattedValue: "b"}, {FormattedValue: "c"}, {FormattedValue: "d"},
+	}}, nil, {Values: []*sheets.CellData{{FormattedValue: "x"}}}}
+	row := func(N int64) (res string) {
+		sheet.GetRowN(N).CellIter(func(cell *Cell) {
+			res += cell.GetString() + "."
+		})
+		return //
+	}
+	upd := &Updater{}
+	upd.MoveColumns(sheet, 3, 1, 0)
+	assert.True(t, upd.reshaped)
+	assert.Equal(t, int64(0), upd.requestQueue[0].request.MoveDimension.DestinationIndex)
+	upd.requestQueue[0].apply(nil)
+	assert.Equal(t, "d.a.b.c.", row(0))
+	assert.Equal(t, ".x...", row(2))
+
+	upd.MoveColumns(sheet, 0, 2, 4) // to the end, counted before the move
+	upd.requestQueue[1].apply(nil)
+	assert.Equal(t, "b.c.d.a.", row(0))
+	assert.Equal(t, "...x.", row(2))
+}
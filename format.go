@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Format applies format to every cell in dbrange. Only the fields that are
+// set in format (or listed in its ForceSendFields) are written, so existing
+// formatting that isn't mentioned is left alone.
+func (upd *Updater) Format(dbrange *DBRange, format *sheets.CellFormat) {
+	upd.queueRequest(&sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range: apiGridRange(dbrange.gridRange),
+			Cell: &sheets.CellData{
+				UserEnteredFormat: format,
+			},
+			Fields: FormatFieldMask(format),
+		},
+	}, nil)
+}
+
+// SetBold is a convenience for the common "bold the header row" case.
+func (upd *Updater) SetBold(dbrange *DBRange, bold bool) {
+	upd.Format(dbrange, &sheets.CellFormat{
+		TextFormat: &sheets.TextFormat{
+			Bold:            bold,
+			ForceSendFields: []string{"Bold"},
+		},
+	})
+}
+
+// SetNumberFormat sets the number format of dbrange.
+// formatType is one of the Sheets types: TEXT, NUMBER, PERCENT, CURRENCY,
+// DATE, TIME, DATE_TIME or SCIENTIFIC. An empty pattern uses the locale
+// default for the type.
+func (upd *Updater) SetNumberFormat(dbrange *DBRange, formatType, pattern string) {
+	upd.Format(dbrange, &sheets.CellFormat{
+		NumberFormat: &sheets.NumberFormat{
+			Type:    formatType,
+			Pattern: pattern,
+		},
+	})
+}
+
+// SetBorders draws a border of the given style (SOLID, SOLID_MEDIUM, DOTTED,
+// ...) around every cell in dbrange. A nil color is black.
+func (upd *Updater) SetBorders(dbrange *DBRange, style string, color *sheets.Color) {
+	if color == nil {
+		color = &sheets.Color{}
+	}
+	border := &sheets.Border{
+		Style: style,
+		Color: color,
+	}
+	upd.Format(dbrange, &sheets.CellFormat{
+		Borders: &sheets.Borders{
+			Top:    border,
+			Bottom: border,
+			Left:   border,
+			Right:  border,
+		},
+	})
+}
+
+// SetColumnWidth sets the width in pixels of every column spanned by dbrange.
+func (upd *Updater) SetColumnWidth(dbrange *DBRange, pixels int64) {
+	upd.queueRequest(&sheets.Request{
+		UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+			Range: &sheets.DimensionRange{
+				SheetId:    dbrange.gridRange.SheetId,
+				Dimension:  "COLUMNS",
+				StartIndex: dbrange.gridRange.StartColumnIndex,
+				EndIndex:   apiGridRange(dbrange.gridRange).EndColumnIndex,
+			},
+			Properties: &sheets.DimensionProperties{
+				PixelSize: pixels,
+			},
+			Fields: "pixelSize",
+		},
+	}, nil)
+}
+
+// FreezeRows freezes the top n rows of sheet. n == 0 unfreezes.
+func (upd *Updater) FreezeRows(sheet *Sheet, n int64) {
+	upd.queueRequest(&sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId: sheet.GetID(),
+				GridProperties: &sheets.GridProperties{
+					FrozenRowCount:  n,
+					ForceSendFields: []string{"FrozenRowCount"},
+				},
+			},
+			Fields: "gridProperties.frozenRowCount",
+		},
+	}, func(reply *sheets.Response) {
+		props := sheet.Sheet.Properties
+		if props.GridProperties == nil {
+			props.GridProperties = &sheets.GridProperties{}
+		}
+		props.GridProperties.FrozenRowCount = n
+	})
+}
+
+// FormatFieldMask returns the RepeatCell field mask covering the fields set
+// in format. TextFormat is expanded one level so that, e.g., setting bold
+// doesn't reset the font; every other field is replaced as a unit.
+func FormatFieldMask(format *sheets.CellFormat) (mask string) {
+	if format == nil {
+		return "userEnteredFormat"
+	}
+	buf, err := json.Marshal(format)
+	if err != nil {
+		return "userEnteredFormat"
+	}
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &top); err != nil {
+		return "userEnteredFormat"
+	}
+	fields := []string{}
+	for k, v := range top {
+		var sub map[string]json.RawMessage
+		switch {
+		case k == "textFormat" && json.Unmarshal(v, &sub) == nil && len(sub) > 0:
+			for sk := range sub {
+				fields = append(fields, "userEnteredFormat.textFormat."+sk)
+			}
+		default:
+			fields = append(fields, "userEnteredFormat."+k)
+		}
+	}
+	if len(fields) == 0 {
+		return "userEnteredFormat"
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
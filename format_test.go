@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestFormatFieldMask(t *testing.T) {
+	format := &sheets.CellFormat{
+		TextFormat: &sheets.TextFormat{
+			Bold:            false,
+			FontSize:        12,
+			ForceSendFields: []string{"Bold"},
+		},
+		NumberFormat:        &sheets.NumberFormat{Type: "CURRENCY"},
+		HorizontalAlignment: "RIGHT",
+	}
+	assert.Equal(t, "userEnteredFormat.horizontalAlignment,"+
+		"userEnteredFormat.numberFormat,"+
+		"userEnteredFormat.textFormat.bold,"+
+		"userEnteredFormat.textFormat.fontSize", FormatFieldMask(format))
+
+	assert.Equal(t, "userEnteredFormat", FormatFieldMask(nil))
+	assert.Equal(t, "userEnteredFormat", FormatFieldMask(&sheets.CellFormat{}))
+	// an empty TextFormat is sent, and so replaced, as a whole
+	assert.Equal(t, "userEnteredFormat.textFormat", FormatFieldMask(&sheets.CellFormat{TextFormat: &sheets.TextFormat{}}))
+
+	upd := &Updater{}
+	upd.SetBold(testRange(t, "A1:F1"), true)
+	req := upd.requestQueue[0].request.RepeatCell
+	assert.Equal(t, "userEnteredFormat.textFormat.bold", req.Fields)
+	assert.Equal(t, int64(6), req.Range.EndColumnIndex)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var ErrFormulaOverwrite = errors.New("update would overwrite a formula")
+
+// Formula is a value that is written as a formula rather than as text:
+//
+//	updater.Update(total, [][]any{{ssdb.Formula("=SUM(E2:E)")}})
+//
+// A plain string starting with "=" is still written as text.
+type Formula string
+
+// HasFormula reports whether the cell holds a formula.
+func (cell *Cell) HasFormula() bool {
+	return cell.GetFormula() != ""
+}
+
+// GetFormula returns the cell's formula, e.g. "=SUM(E2:E)", or "" if it has
+// none. GetString and the typed getters return the computed value.
+func (cell *Cell) GetFormula() string {
+	if cell == nil || cell.Cell == nil {
+		return ""
+	}
+	return cellFormula(cell.Cell)
+}
+
+func cellFormula(cell *sheets.CellData) string {
+	if cell == nil || cell.UserEnteredValue == nil || cell.UserEnteredValue.FormulaValue == nil {
+		return ""
+	}
+	return *cell.UserEnteredValue.FormulaValue
+}
+
+// GuardFormulas makes Sync fail with ErrFormulaOverwrite, and write
+// nothing, if an update would replace a formula with anything other than
+// another Formula.
+func (upd *Updater) GuardFormulas(guard bool) {
+	upd.Lock()
+	upd.guardFormulas = guard
+	upd.Unlock()
+}
+
+// checkFormulas enforces GuardFormulas.
+func (upd *Updater) checkFormulas() error {
+	if !upd.guardFormulas {
+		return nil
+	}
+	for _, update := range upd.updateQueue {
+		gridRange := update.dbRange.gridRange
+		var err error
+		update.dbRange.sheet.rangeIter(update.dbRange, func(row, col int64, cell *sheets.CellData) {
+			y, x := row-gridRange.StartRowIndex, col-gridRange.StartColumnIndex
+			if err != nil || cellFormula(cell) == "" || y >= int64(len(update.newdata)) || x >= int64(len(update.newdata[y])) {
+				return
+			}
+			switch update.newdata[y][x].(type) {
+			case Formula, skipValue:
+			default:
+				at := FormatA1(update.dbRange.sheet.Sheet.Properties.Title, GenRange(gridRange.SheetId, col, col+1, row, row+1))
+				err = fmt.Errorf("%s: %s: %w", at, cellFormula(cell), ErrFormulaOverwrite)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestFormulaGuard(t *testing.T) {
+	sum := "=SUM(A1:A2)"
+	one := 1.0
+	dbrange := testRange(t, "A3:B3")
+	dbrange.sheet.Sheet.Data[0].RowData = []*sheets.RowData{{}, {}, {
+		Values: []*sheets.CellData{
+			{UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &sum}, FormattedValue: "3"},
+			{UserEnteredValue: &sheets.ExtendedValue{NumberValue: &one}, FormattedValue: "1"},
+		},
+	}}
+	cell := dbrange.sheet.GetRowN(2).GetCellN(0)
+	assert.True(t, cell.HasFormula())
+	assert.Equal(t, sum, cell.GetFormula())
+	assert.Equal(t, "3", cell.GetString())
+	assert.False(t, dbrange.sheet.GetRowN(2).GetCellN(1).HasFormula())
+
+	upd := &Updater{ssdbHandle: &SSDB{}}
+	upd.updateQueue = []*updateItem{{dbRange: dbrange, newdata: [][]any{{"4", "5"}}}}
+	assert.NoError(t, upd.checkFormulas())
+	upd.GuardFormulas(true)
+	err := upd.checkFormulas()
+	assert.ErrorIs(t, err, ErrFormulaOverwrite)
+	assert.ErrorContains(t, err, "Sheet1!A3")
+	upd.updateQueue[0].newdata = [][]any{{Skip, "5"}}
+	assert.NoError(t, upd.checkFormulas())
+	upd.updateQueue[0].newdata = [][]any{{Formula("=A1*A2"), "5"}}
+	assert.NoError(t, upd.checkFormulas())
+
+	upd.keepWritten()
+	assert.Equal(t, "=A1*A2", cell.GetFormula())
+	assert.Equal(t, "=A1", *defaultLocale.extendedValue(Formula("=A1")).FormulaValue)
+	assert.Equal(t, "=A1", *defaultLocale.extendedValue("=A1").StringValue)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"math"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// The operations in this file never modify their receiver or arguments;
+// each result has its own gridRange. Ranges on different sheets have
+// nothing in common, so Intersect and Union return nil for them.
+
+// derive returns a new range on the same sheet as dbrange.
+func (dbrange *DBRange) derive(gridRange *sheets.GridRange) (res *DBRange) {
+	res = &DBRange{
+		gridRange: gridRange,
+		sheet:     dbrange.sheet,
+	}
+	res.symbolicRange = res.String()
+	return //
+}
+
+func (dbrange *DBRange) sameSheet(other *DBRange) bool {
+	return dbrange.gridRange.SheetId == other.gridRange.SheetId
+}
+
+// fromEndIndex undoes endIndex.
+func fromEndIndex(end int64) int64 {
+	if end == math.MaxInt64 {
+		return Unbounded
+	}
+	return end
+}
+
+// Size returns the number of rows and columns in dbrange. An Unbounded
+// dimension is reported as Unbounded.
+func (dbrange *DBRange) Size() (rows, cols int64) {
+	rows, cols = Unbounded, Unbounded
+	if dbrange.gridRange.EndRowIndex != Unbounded {
+		rows = dbrange.gridRange.EndRowIndex - dbrange.gridRange.StartRowIndex
+	}
+	if dbrange.gridRange.EndColumnIndex != Unbounded {
+		cols = dbrange.gridRange.EndColumnIndex - dbrange.gridRange.StartColumnIndex
+	}
+	return //
+}
+
+// Intersect returns the cells common to dbrange and other, or nil if there
+// are none.
+func (dbrange *DBRange) Intersect(other *DBRange) *DBRange {
+	if !dbrange.Overlaps(other) {
+		return nil
+	}
+	a, b := dbrange.gridRange, other.gridRange
+	return dbrange.derive(&sheets.GridRange{
+		SheetId:          a.SheetId,
+		StartRowIndex:    max(a.StartRowIndex, b.StartRowIndex),
+		EndRowIndex:      fromEndIndex(min(endIndex(a.EndRowIndex), endIndex(b.EndRowIndex))),
+		StartColumnIndex: max(a.StartColumnIndex, b.StartColumnIndex),
+		EndColumnIndex:   fromEndIndex(min(endIndex(a.EndColumnIndex), endIndex(b.EndColumnIndex))),
+	})
+}
+
+// Union returns the bounding box of dbrange and other, or nil if they are
+// on different sheets.
+func (dbrange *DBRange) Union(other *DBRange) *DBRange {
+	if !dbrange.sameSheet(other) {
+		return nil
+	}
+	return dbrange.Extend(other)
+}
+
+// Contains reports whether every cell of other is in dbrange.
+func (dbrange *DBRange) Contains(other *DBRange) bool {
+	a, b := dbrange.gridRange, other.gridRange
+	return dbrange.sameSheet(other) &&
+		a.StartRowIndex <= b.StartRowIndex &&
+		a.StartColumnIndex <= b.StartColumnIndex &&
+		endIndex(a.EndRowIndex) >= endIndex(b.EndRowIndex) &&
+		endIndex(a.EndColumnIndex) >= endIndex(b.EndColumnIndex)
+}
+
+// Overlaps reports whether dbrange and other share at least one cell.
+func (dbrange *DBRange) Overlaps(other *DBRange) bool {
+	return gridRangesOverlap(dbrange.gridRange, other.gridRange)
+}
+
+// Offset returns dbrange moved down rows and right cols (negative values
+// move up and left). It returns nil if the result would fall off the top
+// or left edge of the sheet.
+func (dbrange *DBRange) Offset(rows, cols int64) *DBRange {
+	gridRange := *dbrange.gridRange
+	gridRange.StartRowIndex += rows
+	gridRange.StartColumnIndex += cols
+	if gridRange.StartRowIndex < 0 || gridRange.StartColumnIndex < 0 {
+		return nil
+	}
+	if gridRange.EndRowIndex != Unbounded {
+		gridRange.EndRowIndex += rows
+	}
+	if gridRange.EndColumnIndex != Unbounded {
+		gridRange.EndColumnIndex += cols
+	}
+	return dbrange.derive(&gridRange)
+}
+
+// Resize returns a range with the same top left corner as dbrange and the
+// given size. Either size may be Unbounded; otherwise it must be positive.
+func (dbrange *DBRange) Resize(rows, cols int64) *DBRange {
+	if rows == 0 || rows < Unbounded || cols == 0 || cols < Unbounded {
+		return nil
+	}
+	gridRange := *dbrange.gridRange
+	gridRange.EndRowIndex, gridRange.EndColumnIndex = Unbounded, Unbounded
+	if rows != Unbounded {
+		gridRange.EndRowIndex = gridRange.StartRowIndex + rows
+	}
+	if cols != Unbounded {
+		gridRange.EndColumnIndex = gridRange.StartColumnIndex + cols
+	}
+	return dbrange.derive(&gridRange)
+}
+
+// bounded clips Unbounded ends of dbrange to the cached extents of its
+// sheet, so the result can be walked cell by cell.
+func (dbrange *DBRange) bounded() *DBRange {
+	gridRange := *dbrange.gridRange
+	if gridRange.EndRowIndex == Unbounded || gridRange.EndColumnIndex == Unbounded {
+		extents := dbrange.sheet.GetExtents().gridRange
+		if gridRange.EndRowIndex == Unbounded {
+			gridRange.EndRowIndex = max(extents.EndRowIndex, gridRange.StartRowIndex)
+		}
+		if gridRange.EndColumnIndex == Unbounded {
+			gridRange.EndColumnIndex = max(extents.EndColumnIndex, gridRange.StartColumnIndex)
+		}
+	}
+	return dbrange.derive(&gridRange)
+}
+
+// Rows splits dbrange into one range per row. An Unbounded row range stops
+// at the last cached row of the sheet.
+func (dbrange *DBRange) Rows() (res []*DBRange) {
+	b := dbrange.bounded().gridRange
+	for r := b.StartRowIndex; r < b.EndRowIndex; r++ {
+		gridRange := *dbrange.gridRange
+		gridRange.StartRowIndex, gridRange.EndRowIndex = r, r+1
+		res = append(res, dbrange.derive(&gridRange))
+	}
+	return //
+}
+
+// Columns splits dbrange into one range per column. An Unbounded column
+// range stops at the last cached column of the sheet.
+func (dbrange *DBRange) Columns() (res []*DBRange) {
+	b := dbrange.bounded().gridRange
+	for c := b.StartColumnIndex; c < b.EndColumnIndex; c++ {
+		gridRange := *dbrange.gridRange
+		gridRange.StartColumnIndex, gridRange.EndColumnIndex = c, c+1
+		res = append(res, dbrange.derive(&gridRange))
+	}
+	return //
+}
+
+// Cells calls f with a single cell range for every cell of dbrange, row by
+// row. Unbounded ends stop at the cached extents of the sheet.
+func (dbrange *DBRange) Cells(f func(cell *DBRange)) {
+	b := dbrange.bounded().gridRange
+	for r := b.StartRowIndex; r < b.EndRowIndex; r++ {
+		for c := b.StartColumnIndex; c < b.EndColumnIndex; c++ {
+			f(dbrange.derive(GenRange(b.SheetId, c, c+1, r, r+1)))
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func testRange(t *testing.T, a1 string) *DBRange {
+	sheet := &Sheet{
+		Sheet: &sheets.Sheet{
+			Properties: &sheets.SheetProperties{Title: "Sheet1"},
+			Data:       []*sheets.GridData{{}},
+		},
+	}
+	_, gridRange, err := ParseA1(a1)
+	assert.NoError(t, err, a1)
+	return &DBRange{gridRange: gridRange, sheet: sheet}
+}
+
+func TestGeometry(t *testing.T) {
+	a := testRange(t, "B2:D5")
+	b := testRange(t, "C4:F9")
+	orig := *a.gridRange
+
+	assert.Equal(t, "Sheet1!C4:D5", a.Intersect(b).String())
+	assert.Equal(t, "Sheet1!B2:F9", a.Union(b).String())
+	assert.Equal(t, "Sheet1!B2:F9", a.Extend(b).String())
+	assert.Nil(t, a.Intersect(testRange(t, "E1:E9")))
+	assert.True(t, a.Overlaps(b))
+	assert.False(t, a.Contains(b))
+	assert.True(t, a.Union(b).Contains(b))
+	assert.True(t, testRange(t, "B:B").Contains(testRange(t, "B7:B900")))
+	assert.Equal(t, "Sheet1!C4:D", testRange(t, "A4:D").Intersect(testRange(t, "C:F")).String())
+
+	assert.Equal(t, "Sheet1!C5:E8", a.Offset(3, 1).String())
+	assert.Nil(t, a.Offset(-2, 0))
+	assert.Equal(t, "Sheet1!B2:C2", a.Resize(1, 2).String())
+	assert.Equal(t, "Sheet1!B2:B", a.Resize(Unbounded, 1).String())
+
+	rows := a.Rows()
+	assert.Len(t, rows, 4)
+	assert.Equal(t, "Sheet1!B3:D3", rows[1].String())
+	cols := a.Columns()
+	assert.Len(t, cols, 3)
+	assert.Equal(t, "Sheet1!D2:D5", cols[2].String())
+	n := 0
+	a.Cells(func(cell *DBRange) {
+		n++
+	})
+	assert.Equal(t, 12, n)
+
+	// none of the above may touch the original
+	assert.Equal(t, orig, *a.gridRange)
+}
+
+func TestDBRangeJSON(t *testing.T) {
+	a := testRange(t, "B2:D5")
+	buf, err := json.Marshal(a)
+	assert.NoError(t, err)
+	assert.Equal(t, `"Sheet1!B2:D5"`, string(buf))
+
+	var b DBRange
+	assert.NoError(t, json.Unmarshal(buf, &b))
+	assert.Equal(t, "Sheet1", b.SheetName())
+	assert.Equal(t, a.GridRange(), b.GridRange())
+	assert.Equal(t, int64(1), b.StartRow())
+	assert.Equal(t, int64(4), b.EndColumn())
+
+	assert.Error(t, json.Unmarshal([]byte(`"Sheet1!B2:"`), &b))
+	assert.NoError(t, json.Unmarshal([]byte(`"MaintenanceFlag"`), &b))
+	assert.Equal(t, "MaintenanceFlag", b.String())
+}
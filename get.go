@@ -41,7 +41,7 @@ func (sheet *Sheet) GetExtents() (res *DBRange) {
 }
 
 func (sheet *Sheet) GetRowN(N int64) *Row {
-	if sheet == nil || len(sheet.Sheet.Data) == 0 || len(sheet.Sheet.Data[0].RowData) < int(N) {
+	if sheet == nil || len(sheet.Sheet.Data) == 0 || len(sheet.Sheet.Data[0].RowData) <= int(N) {
 		return nil
 	}
 	return &Row{
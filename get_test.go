@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestGetRowN(t *testing.T) {
+	sheet := testRange(t, "A1").sheet
+	sheet.Sheet.Data[0].RowData = []*sheets.RowData{
+		{Values: []*sheets.CellData{{FormattedValue: "a"}}},
+		{Values: []*sheets.CellData{{FormattedValue: "b"}}},
+	}
+	assert.Equal(t, "b", sheet.GetRowN(1).GetCellN(0).GetString())
+	assert.Nil(t, sheet.GetRowN(2)) // one past the end used to panic
+	assert.Nil(t, sheet.GetRowN(3))
+	assert.Nil(t, (*Sheet)(nil).GetRowN(0))
+}
@@ -9,6 +9,7 @@ package ssdb
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 func (db *SSDB) Loader(ctx context.Context) (err error) {
@@ -18,9 +19,27 @@ func (db *SSDB) Loader(ctx context.Context) (err error) {
 		return //
 	}
 	db.spreadsheet = spreadsheet
+	db.locale = nil
+	db.generation.Add(1)
 	return //
 }
 
 func (db *SSDB) ReloadDBGet(ctx context.Context) (err error) {
 	return db.Loader(ctx)
 }
+
+// Location returns the spreadsheet's time zone (File > Settings in the
+// UI). It falls back to UTC if the zone is unset or unknown.
+func (db *SSDB) Location() *time.Location {
+	return db.Locale().Location
+}
+
+// Generation counts the changes to the cache: it goes up on every Loader,
+// Merge and Sync. Anything derived from the cache, such as an index, is
+// stale once the generation it was built at has passed.
+func (db *SSDB) Generation() uint64 {
+	if db == nil {
+		return 0
+	}
+	return db.generation.Load()
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestGeneration(t *testing.T) {
+	assert.Equal(t, uint64(0), (*SSDB)(nil).Generation())
+	db := &SSDB{spreadsheet: &sheets.Spreadsheet{Sheets: []*sheets.Sheet{{
+		Properties: &sheets.SheetProperties{Title: "Sheet1"},
+		Data:       []*sheets.GridData{{RowData: []*sheets.RowData{{Values: []*sheets.CellData{{}}}}}},
+	}}}}
+	err := db.Merge(&sheets.BatchGetValuesResponse{ValueRanges: []*sheets.ValueRange{{
+		Range:  "Sheet1!A1",
+		Values: [][]any{{"x"}},
+	}}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), db.Generation())
+	assert.Equal(t, "x", db.SheetLookup("Sheet1").GetRowN(0).GetCellN(0).GetString())
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Locale describes how a spreadsheet shows numbers and dates. It follows
+// the Locale and TimeZone settings of the spreadsheet (File > Settings),
+// and is used both to read cell text and to turn written text into values.
+type Locale struct {
+	Name          string // as in the spreadsheet properties, e.g. "de_DE"
+	Decimal       string // decimal separator
+	Group         string // thousands separator
+	Currency      string // currency symbol
+	CurrencyAfter bool   // "5 €" rather than "€5"
+	PercentSpace  bool   // "12 %" rather than "12%"
+	DateLayout    string // time layout for dates, e.g. "1/2/2006"
+	Location      *time.Location
+}
+
+// Number format types, as used in sheets.NumberFormat.
+const (
+	NumberKind   = "NUMBER"
+	CurrencyKind = "CURRENCY"
+	PercentKind  = "PERCENT"
+)
+
+const nbsp = "\u00a0"
+
+// localeFormats holds the separators and date layout by language; the
+// country can override them in localeOverrides.
+var localeFormats = map[string]Locale{
+	"en": {Decimal: ".", Group: ",", DateLayout: "02/01/2006"},
+	"de": {Decimal: ",", Group: ".", CurrencyAfter: true, PercentSpace: true, DateLayout: "02.01.2006"},
+	"fr": {Decimal: ",", Group: nbsp, CurrencyAfter: true, PercentSpace: true, DateLayout: "02/01/2006"},
+	"es": {Decimal: ",", Group: ".", CurrencyAfter: true, PercentSpace: true, DateLayout: "2/1/2006"},
+	"it": {Decimal: ",", Group: ".", CurrencyAfter: true, DateLayout: "2/1/2006"},
+	"pt": {Decimal: ",", Group: ".", DateLayout: "02/01/2006"},
+	"nl": {Decimal: ",", Group: ".", DateLayout: "2-1-2006"},
+	"da": {Decimal: ",", Group: ".", CurrencyAfter: true, PercentSpace: true, DateLayout: "2.1.2006"},
+	"sv": {Decimal: ",", Group: nbsp, CurrencyAfter: true, PercentSpace: true, DateLayout: "2006-01-02"},
+	"nb": {Decimal: ",", Group: nbsp, CurrencyAfter: true, PercentSpace: true, DateLayout: "02.01.2006"},
+	"fi": {Decimal: ",", Group: nbsp, CurrencyAfter: true, PercentSpace: true, DateLayout: "2.1.2006"},
+	"pl": {Decimal: ",", Group: nbsp, CurrencyAfter: true, DateLayout: "2.01.2006"},
+	"cs": {Decimal: ",", Group: nbsp, CurrencyAfter: true, PercentSpace: true, DateLayout: "2.1.2006"},
+	"ru": {Decimal: ",", Group: nbsp, CurrencyAfter: true, PercentSpace: true, DateLayout: "02.01.2006"},
+	"uk": {Decimal: ",", Group: nbsp, CurrencyAfter: true, DateLayout: "02.01.2006"},
+	"hu": {Decimal: ",", Group: nbsp, CurrencyAfter: true, DateLayout: "2006. 01. 02."},
+	"tr": {Decimal: ",", Group: ".", DateLayout: "02.01.2006"},
+	"ja": {Decimal: ".", Group: ",", DateLayout: "2006/01/02"},
+	"zh": {Decimal: ".", Group: ",", DateLayout: "2006/1/2"},
+	"ko": {Decimal: ".", Group: ",", DateLayout: "2006. 1. 2"},
+}
+
+var localeOverrides = map[string]func(locale *Locale){
+	"en_US": func(locale *Locale) { locale.DateLayout = "1/2/2006" },
+	"en_CA": func(locale *Locale) { locale.DateLayout = "2006-01-02" },
+	"de_CH": func(locale *Locale) { locale.Decimal, locale.Group = ".", "’" },
+	"fr_CA": func(locale *Locale) { locale.DateLayout = "2006-01-02" },
+	"fr_CH": func(locale *Locale) { locale.Decimal, locale.Group = ",", "’" },
+	"pt_PT": func(locale *Locale) { locale.Group, locale.CurrencyAfter = nbsp, true },
+	"es_MX": func(locale *Locale) { locale.Decimal, locale.Group, locale.CurrencyAfter = ".", ",", false },
+}
+
+var currencies = map[string]string{
+	"US": "$", "CA": "$", "AU": "$", "NZ": "$", "MX": "$", "SG": "$", "HK": "$",
+	"GB": "£", "JP": "¥", "CN": "¥", "IN": "₹", "KR": "₩", "RU": "₽", "UA": "₴",
+	"BR": "R$", "CH": "CHF", "PL": "zł", "CZ": "Kč", "HU": "Ft", "TR": "₺",
+	"IL": "₪", "ZA": "R", "SE": "kr", "NO": "kr", "DK": "kr.",
+	"DE": "€", "FR": "€", "ES": "€", "IT": "€", "NL": "€", "BE": "€", "AT": "€",
+	"PT": "€", "IE": "€", "FI": "€", "GR": "€", "LU": "€", "SK": "€", "SI": "€",
+}
+
+// NewLocale returns the Locale for a spreadsheet locale such as "en_US"
+// and an IANA time zone. Unknown locales read and write like en_US, and
+// an unknown zone is treated as UTC.
+func NewLocale(name, timeZone string) *Locale {
+	lang, country, _ := strings.Cut(name, "_")
+	locale, ok := localeFormats[lang]
+	if !ok {
+		locale, country = localeFormats["en"], "US"
+		localeOverrides["en_US"](&locale)
+	}
+	if f, ok := localeOverrides[lang+"_"+country]; ok {
+		f(&locale)
+	}
+	locale.Name = name
+	locale.Currency = "$"
+	if sym, ok := currencies[country]; ok {
+		locale.Currency = sym
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	locale.Location = loc
+	return &locale
+}
+
+// Locale returns the locale of the spreadsheet. A nil db, as in cells
+// built without one, has the default locale.
+func (db *SSDB) Locale() *Locale {
+	if db == nil {
+		return defaultLocale
+	}
+	if db.locale == nil {
+		name, timeZone := "en_US", "UTC"
+		if db.spreadsheet != nil && db.spreadsheet.Properties != nil {
+			name, timeZone = db.spreadsheet.Properties.Locale, db.spreadsheet.Properties.TimeZone
+		}
+		db.locale = NewLocale(name, timeZone)
+	}
+	return db.locale
+}
+
+// defaultLocale is used where no spreadsheet is at hand.
+var defaultLocale = NewLocale("en_US", "UTC")
+
+// ParseNumber reads a number the way a cell in this locale shows it:
+// "1,250.50", "-3", "(42)", "$1,250", "12%", "1.3E+03" in en_US, "5 €" in
+// de_DE. Only the locale's own currency symbol is taken, on the side the
+// locale writes it, so "R2" or "A$1" is text in en_US. kind is NumberKind,
+// CurrencyKind or PercentKind; percentages are returned as fractions, so
+// "12%" is 0.12.
+func (locale *Locale) ParseNumber(text string) (val float64, kind string, ok bool) {
+	dec, kind, ok := locale.ParseDecimal(text)
+	if !ok {
+		return 0, "", false
+	}
+	val, err := strconv.ParseFloat(dec, 64)
+	return val, kind, err == nil
+}
+
+// ParseDecimal is ParseNumber without the conversion to float64: the value
+// is returned as a plain decimal string ("-1250.50", "0.125" for "12.5%")
+// with exactly the digits of the text.
+func (locale *Locale) ParseDecimal(text string) (dec, kind string, ok bool) {
+	s := strings.TrimFunc(text, unicode.IsSpace)
+	kind = NumberKind
+	signs := 0
+	neg := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s, neg, signs = s[1:len(s)-1], true, 1
+	}
+	for trimmed := true; trimmed; {
+		trimmed = false
+		s = strings.TrimFunc(s, unicode.IsSpace)
+		for _, sign := range []string{"-", "−", "+"} {
+			if rest, found := strings.CutPrefix(s, sign); found {
+				s, neg, trimmed = rest, sign != "+", true
+				signs++
+			}
+		}
+		if rest, found := strings.CutSuffix(s, "%"); found && kind == NumberKind {
+			s, kind, trimmed = rest, PercentKind, true
+		}
+		if kind == NumberKind {
+			rest, found := strings.CutPrefix(s, locale.Currency)
+			if locale.CurrencyAfter {
+				rest, found = strings.CutSuffix(s, locale.Currency)
+			}
+			if found {
+				s, kind, trimmed = rest, CurrencyKind, true
+			}
+		}
+	}
+	if signs > 1 {
+		return "", "", false
+	}
+	mant, exp, hasExp := strings.Cut(strings.ToUpper(s), "E")
+	shift := 0
+	if hasExp {
+		n, err := strconv.Atoi(exp)
+		if err != nil {
+			return "", "", false
+		}
+		shift = n
+	}
+	if kind == PercentKind {
+		shift -= 2
+	}
+	intPart, frac, _ := strings.Cut(mant, locale.Decimal)
+	intPart, ok = locale.ungroup(intPart)
+	if !ok || !allDigits(frac) || intPart == "" && frac == "" {
+		return "", "", false
+	}
+	dec = shiftDecimal(intPart, frac, shift)
+	if neg {
+		dec = "-" + dec
+	}
+	return dec, kind, true
+}
+
+// ungroup removes thousands separators from the integer part of a number.
+// Separators must sit every three digits, which keeps "1.5" from being
+// read as 15 in a locale that groups with dots.
+func (locale *Locale) ungroup(s string) (digits string, ok bool) {
+	var groups []string
+	start := 0
+	for i, r := range s {
+		if locale.isGroup(r) {
+			groups = append(groups, s[start:i])
+			start = i + len(string(r))
+		}
+	}
+	groups = append(groups, s[start:])
+	if len(groups) == 1 {
+		return s, allDigits(s)
+	}
+	for i, group := range groups {
+		if !allDigits(group) || group == "" || len(group) > 3 || i > 0 && len(group) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+func (locale *Locale) isSpaceGroup() bool {
+	return strings.TrimFunc(locale.Group, unicode.IsSpace) == ""
+}
+
+func (locale *Locale) isGroup(r rune) bool {
+	switch {
+	case locale.isSpaceGroup():
+		return unicode.IsSpace(r)
+	case locale.Group == "’":
+		return r == '’' || r == '\''
+	}
+	return string(r) == locale.Group
+}
+
+func allDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// shiftDecimal moves the decimal point of intPart.frac right by shift
+// places (left if negative) without touching the digits.
+func shiftDecimal(intPart, frac string, shift int) string {
+	digits := intPart + frac
+	point := len(intPart) + shift
+	if point < 0 {
+		digits = strings.Repeat("0", -point) + digits
+		point = 0
+	}
+	if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	whole := strings.TrimLeft(digits[:point], "0")
+	if whole == "" {
+		whole = "0"
+	}
+	if point == len(digits) {
+		return whole
+	}
+	return whole + "." + digits[point:]
+}
+
+// FormatNumber formats val with the locale's separators and decimals
+// places after the point; -1 uses as many as needed.
+func (locale *Locale) FormatNumber(val float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(val), 'f', decimals, 64)
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	if val < 0 {
+		b.WriteString("-")
+	}
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(locale.Group)
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteString(locale.Decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// FormatCurrency formats val as an amount in the locale's currency.
+func (locale *Locale) FormatCurrency(val float64, decimals int) string {
+	s := locale.FormatNumber(math.Abs(val), decimals)
+	if locale.CurrencyAfter {
+		s += nbsp + locale.Currency
+	} else {
+		s = locale.Currency + s
+	}
+	if val < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// FormatPercent formats a fraction as a percentage, so 0.12 is "12%".
+func (locale *Locale) FormatPercent(val float64, decimals int) string {
+	s := locale.FormatNumber(val*100, decimals)
+	if locale.PercentSpace {
+		s += nbsp
+	}
+	return s + "%"
+}
+
+// FormatDate formats the date of t, in the locale's time zone.
+func (locale *Locale) FormatDate(t time.Time) string {
+	return t.In(locale.Location).Format(locale.DateLayout)
+}
+
+// FormatDateTime formats t with the time of day, in the locale's time zone.
+func (locale *Locale) FormatDateTime(t time.Time) string {
+	return t.In(locale.Location).Format(locale.DateLayout + " 15:04:05")
+}
+
+var isoLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var clockLayouts = []string{"", " 15:04:05", " 15:04", " 3:04:05 PM", " 3:04 PM"}
+
+// parseLayout relaxes a date layout so that it also reads unpadded days
+// and months.
+var parseLayout = strings.NewReplacer("02", "2", "01", "1")
+
+// ParseTime reads a date, with or without a time of day, written in the
+// locale's date order or as ISO 8601. Times without a zone are taken to be
+// in the locale's time zone.
+func (locale *Locale) ParseTime(text string) (val time.Time, ok bool) {
+	text = strings.TrimSpace(text)
+	for _, layout := range isoLayouts {
+		if val, err := time.ParseInLocation(layout, text, locale.Location); err == nil {
+			return val, true
+		}
+	}
+	date := parseLayout.Replace(locale.DateLayout)
+	for _, clock := range clockLayouts {
+		if val, err := time.ParseInLocation(date+clock, text, locale.Location); err == nil {
+			return val, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestParseNumber(t *testing.T) {
+	us := NewLocale("en_US", "America/New_York")
+	de := NewLocale("de_DE", "Europe/Berlin")
+	fr := NewLocale("fr_FR", "Europe/Paris")
+	za := NewLocale("en_ZA", "Africa/Johannesburg")
+	cases := []struct {
+		locale *Locale
+		text   string
+		dec    string
+		kind   string
+		ok     bool
+	}{
+		{us, "1,250.00", "1250.00", NumberKind, true},
+		{us, "-3", "-3", NumberKind, true},
+		{us, "(42)", "-42", NumberKind, true},
+		{us, "$1,250", "1250", CurrencyKind, true},
+		{us, "-$5.25", "-5.25", CurrencyKind, true},
+		{us, "€5", "", "", false},
+		{us, "R2", "", "", false},
+		{us, "-R5", "", "", false},
+		{us, "2R", "", "", false},
+		{us, "A$1", "", "", false},
+		{us, "C$3", "", "", false},
+		{us, "kr5", "", "", false},
+		{us, "Ft10", "", "", false},
+		{us, "5$", "", "", false},
+		{us, "12%", "0.12", PercentKind, true},
+		{us, "12.5 %", "0.125", PercentKind, true},
+		{us, "1.3E+03", "1300", NumberKind, true},
+		{us, ".5", "0.5", NumberKind, true},
+		{us, "1.234,5", "", "", false},
+		{us, "1,2345", "", "", false},
+		{us, "--3", "", "", false},
+		{us, "$", "", "", false},
+		{us, "n/a", "", "", false},
+		{de, "1.234,5", "1234.5", NumberKind, true},
+		{de, "5 €", "5", CurrencyKind, true},
+		{de, "12 %", "0.12", PercentKind, true},
+		{de, "1.5", "", "", false},
+		{de, "€5", "", "", false},
+		{za, "R2", "2", CurrencyKind, true},
+		{fr, "1 234,5", "1234.5", NumberKind, true},
+	}
+	for _, tc := range cases {
+		dec, kind, ok := tc.locale.ParseDecimal(tc.text)
+		assert.Equal(t, tc.ok, ok, tc.text)
+		assert.Equal(t, tc.dec, dec, tc.text)
+		assert.Equal(t, tc.kind, kind, tc.text)
+	}
+}
+
+func TestFormatLocale(t *testing.T) {
+	us := NewLocale("en_US", "America/New_York")
+	de := NewLocale("de_DE", "Europe/Berlin")
+	assert.Equal(t, "1,234,567.5", us.FormatNumber(1234567.5, -1))
+	assert.Equal(t, "1.234.567,50", de.FormatNumber(1234567.5, 2))
+	assert.Equal(t, "-$5.25", us.FormatCurrency(-5.25, 2))
+	assert.Equal(t, "5,00 €", de.FormatCurrency(5, 2))
+	assert.Equal(t, "12.5%", us.FormatPercent(0.125, -1))
+	assert.Equal(t, "12 %", de.FormatPercent(0.12, 0))
+
+	when := time.Date(2025, 7, 4, 16, 30, 0, 0, time.UTC)
+	assert.Equal(t, "7/4/2025", us.FormatDate(when))
+	assert.Equal(t, "04.07.2025 18:30:00", de.FormatDateTime(when))
+
+	for _, locale := range []*Locale{us, de} {
+		text := locale.FormatDateTime(when)
+		got, ok := locale.ParseTime(text)
+		assert.True(t, ok, text)
+		assert.True(t, when.Equal(got), text)
+	}
+	got, ok := de.ParseTime("4.7.2025")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 7, 4, 0, 0, 0, 0, de.Location), got)
+}
+
+func TestExtendedValue(t *testing.T) {
+	de := NewLocale("de_DE", "Europe/Berlin")
+	assert.Equal(t, 1.5, *de.extendedValue(1.5).NumberValue)
+	assert.Equal(t, 1234.5, *de.extendedValue("1.234,5").NumberValue)
+	assert.Equal(t, "1.5", *de.extendedValue("1.5").StringValue)
+	assert.Nil(t, de.extendedValue(""))
+	assert.Equal(t, 1250.0, *defaultLocale.extendedValue("$1,250").NumberValue)
+	assert.True(t, *de.extendedValue(true).BoolValue)
+	assert.False(t, *de.extendedValue(false).BoolValue)
+}
+
+func TestCurrencyRoundTrip(t *testing.T) {
+	dbrange := testRange(t, "A1:D1")
+	dbrange.sheet.Sheet.Data[0].RowData = []*sheets.RowData{{Values: []*sheets.CellData{{}, {}, {}, {
+		UserEnteredFormat: &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: PercentKind, Pattern: "0.0%"}},
+	}}}}
+	rowData := BuildRowdataAny([][]any{{"R2", "A$1", "12%", "5%"}})
+	cells := rowData[0].Values
+
+	assert.Equal(t, "R2", *cells[0].UserEnteredValue.StringValue)
+	assert.Nil(t, cells[0].UserEnteredFormat.NumberFormat)
+	assert.Equal(t, "A$1", *cells[1].UserEnteredValue.StringValue)
+	assert.Nil(t, cells[1].UserEnteredFormat.NumberFormat)
+	assert.Equal(t, 0.12, *cells[2].UserEnteredValue.NumberValue)
+	assert.Equal(t, PercentKind, cells[2].UserEnteredFormat.NumberFormat.Type)
+
+	reqs := numberFormatRequests(dbrange, rowData)
+	assert.Len(t, reqs, 1) // D1 keeps its own percent format
+	assert.Equal(t, int64(2), reqs[0].RepeatCell.Range.StartColumnIndex)
+	assert.Equal(t, PercentKind, reqs[0].RepeatCell.Cell.UserEnteredFormat.NumberFormat.Type)
+
+	// what the sheet shows after the write, read back into the cache
+	for i, shown := range []string{"R2", "A$1", "12%"} {
+		cell := genCell(&sheets.CellData{}, shown, defaultLocale)
+		got := &Cell{Cell: cell}
+		assert.Equal(t, shown, got.GetString(), shown)
+		if i < 2 {
+			assert.Equal(t, shown, *cell.EffectiveValue.StringValue)
+			_, err := got.GetFloat()
+			assert.ErrorIs(t, err, ErrCellType, shown)
+		}
+	}
+	f, err := (&Cell{Cell: genCell(&sheets.CellData{}, "12%", defaultLocale)}).GetFloat()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.12, f)
+
+	cells = BuildRowdataAny([][]any{{"$5"}})[0].Values
+	assert.Equal(t, 5.0, *cells[0].UserEnteredValue.NumberValue)
+	assert.Equal(t, CurrencyKind, cells[0].UserEnteredFormat.NumberFormat.Type)
+}
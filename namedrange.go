@@ -0,0 +1,193 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var ErrNamedRangeNotFound = errors.New("named range not found")
+var ErrDuplicateNamedRange = errors.New("duplicate named range")
+
+// NamedRanges returns the names of the spreadsheet's named ranges.
+func (db *SSDB) NamedRanges() (names []string) {
+	for _, nr := range db.spreadsheet.NamedRanges {
+		names = append(names, nr.Name)
+	}
+	return //
+}
+
+// NamedRangeLookup resolves a named range (e.g. "MaintenanceFlag") into a
+// DBRange. Names are case insensitive, as in Sheets. It returns nil if
+// there is no such name or its sheet is gone.
+func (db *SSDB) NamedRangeLookup(name string) (dbRange *DBRange) {
+	nr := db.findNamedRange(name)
+	if nr == nil || nr.Range == nil {
+		return nil
+	}
+	var foundSheet *Sheet
+	db.SheetIter(func(sheetname string, sheet *Sheet) {
+		if sheet.GetID() == nr.Range.SheetId {
+			foundSheet = sheet
+		}
+	})
+	if foundSheet == nil {
+		return nil
+	}
+	dbRange = &DBRange{
+		gridRange: gridRangeFromAPI(nr.Range),
+		sheet:     foundSheet,
+	}
+	dbRange.symbolicRange = dbRange.String()
+	return //
+}
+
+func (db *SSDB) findNamedRange(name string) *sheets.NamedRange {
+	for _, nr := range db.spreadsheet.NamedRanges {
+		if strings.EqualFold(nr.Name, name) {
+			return nr
+		}
+	}
+	return nil
+}
+
+func (db *SSDB) findNamedRangeByID(id string) *sheets.NamedRange {
+	for _, nr := range db.spreadsheet.NamedRanges {
+		if nr.NamedRangeId == id {
+			return nr
+		}
+	}
+	return nil
+}
+
+// nameTaken reports whether a named range other than the one with ID id
+// will be called name once the requests queued on upd have run. Names are
+// compared case insensitively; a name deleted or renamed away earlier in
+// the batch is free again.
+func (upd *Updater) nameTaken(name, id string) bool {
+	names := map[string]string{} // by named range ID
+	for _, nr := range upd.ssdbHandle.spreadsheet.NamedRanges {
+		names[nr.NamedRangeId] = nr.Name
+	}
+	upd.Lock()
+	defer upd.Unlock()
+
+	for i, item := range upd.requestQueue {
+		switch req := item.request; {
+		case req.AddNamedRange != nil:
+			names["queued "+strconv.Itoa(i)] = req.AddNamedRange.NamedRange.Name // no ID yet
+		case req.UpdateNamedRange != nil && req.UpdateNamedRange.Fields == "name":
+			names[req.UpdateNamedRange.NamedRange.NamedRangeId] = req.UpdateNamedRange.NamedRange.Name
+		case req.DeleteNamedRange != nil:
+			delete(names, req.DeleteNamedRange.NamedRangeId)
+		}
+	}
+	for other, taken := range names {
+		if other != id && strings.EqualFold(taken, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddNamedRange creates a named range covering dbrange.
+func (upd *Updater) AddNamedRange(name string, dbrange *DBRange) (err error) {
+	db := upd.ssdbHandle
+	if upd.nameTaken(name, "") {
+		return ErrDuplicateNamedRange
+	}
+	gridRange := apiGridRange(dbrange.gridRange)
+	upd.queueRequest(&sheets.Request{
+		AddNamedRange: &sheets.AddNamedRangeRequest{
+			NamedRange: &sheets.NamedRange{
+				Name:  name,
+				Range: gridRange,
+			},
+		},
+	}, func(reply *sheets.Response) {
+		nr := &sheets.NamedRange{
+			Name:  name,
+			Range: gridRange,
+		}
+		if reply != nil && reply.AddNamedRange != nil && reply.AddNamedRange.NamedRange != nil {
+			nr = reply.AddNamedRange.NamedRange
+		}
+		db.spreadsheet.NamedRanges = append(db.spreadsheet.NamedRanges, nr)
+	})
+	return nil
+}
+
+// UpdateNamedRange moves an existing named range to cover dbrange.
+func (upd *Updater) UpdateNamedRange(name string, dbrange *DBRange) (err error) {
+	nr := upd.ssdbHandle.findNamedRange(name)
+	if nr == nil {
+		return ErrNamedRangeNotFound
+	}
+	gridRange := apiGridRange(dbrange.gridRange)
+	upd.queueRequest(&sheets.Request{
+		UpdateNamedRange: &sheets.UpdateNamedRangeRequest{
+			NamedRange: &sheets.NamedRange{
+				NamedRangeId: nr.NamedRangeId,
+				Range:        gridRange,
+			},
+			Fields: "range",
+		},
+	}, func(reply *sheets.Response) {
+		nr.Range = gridRange
+	})
+	return nil
+}
+
+// RenameNamedRange changes the name of a named range, keeping its cells.
+func (upd *Updater) RenameNamedRange(oldName, newName string) (err error) {
+	db := upd.ssdbHandle
+	nr := db.findNamedRange(oldName)
+	switch {
+	case nr == nil:
+		return ErrNamedRangeNotFound
+	case upd.nameTaken(newName, nr.NamedRangeId):
+		return ErrDuplicateNamedRange
+	}
+	upd.queueRequest(&sheets.Request{
+		UpdateNamedRange: &sheets.UpdateNamedRangeRequest{
+			NamedRange: &sheets.NamedRange{
+				NamedRangeId: nr.NamedRangeId,
+				Name:         newName,
+			},
+			Fields: "name",
+		},
+	}, func(reply *sheets.Response) {
+		nr.Name = newName
+	})
+	return nil
+}
+
+// DeleteNamedRange removes a named range. The cells are not touched.
+func (upd *Updater) DeleteNamedRange(name string) (err error) {
+	db := upd.ssdbHandle
+	nr := db.findNamedRange(name)
+	if nr == nil {
+		return ErrNamedRangeNotFound
+	}
+	upd.queueRequest(&sheets.Request{
+		DeleteNamedRange: &sheets.DeleteNamedRangeRequest{
+			NamedRangeId: nr.NamedRangeId,
+		},
+	}, func(reply *sheets.Response) {
+		for i, elem := range db.spreadsheet.NamedRanges {
+			if elem == nr {
+				db.spreadsheet.NamedRanges = append(db.spreadsheet.NamedRanges[:i], db.spreadsheet.NamedRanges[i+1:]...)
+				break
+			}
+		}
+	})
+	return nil
+}
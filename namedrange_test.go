@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func testNamedRanges() *SSDB {
+	return &SSDB{spreadsheet: &sheets.Spreadsheet{
+		Sheets: []*sheets.Sheet{
+			{Properties: &sheets.SheetProperties{Title: "Sheet1"}},
+			{Properties: &sheets.SheetProperties{Title: "Data", SheetId: 5}},
+		},
+		NamedRanges: []*sheets.NamedRange{
+			{Name: "Flag", NamedRangeId: "f", Range: &sheets.GridRange{SheetId: 5, StartRowIndex: 1, EndRowIndex: 2, StartColumnIndex: 1, EndColumnIndex: 2}},
+			{Name: "Amounts", NamedRangeId: "a", Range: &sheets.GridRange{SheetId: 5, StartColumnIndex: 2, EndColumnIndex: 3}},
+			{Name: "Tail", NamedRangeId: "t", Range: &sheets.GridRange{StartRowIndex: 3}},
+			{Name: "Gone", NamedRangeId: "g", Range: &sheets.GridRange{SheetId: 9}},
+		},
+	}}
+}
+
+func TestNamedRangeLookup(t *testing.T) {
+	db := testNamedRanges()
+	assert.Equal(t, []string{"Flag", "Amounts", "Tail", "Gone"}, db.NamedRanges())
+	assert.Equal(t, "Data!B2", db.NamedRangeLookup("Flag").String())
+	assert.Equal(t, "Data!C:C", db.NamedRangeLookup("Amounts").String())
+	tail := db.NamedRangeLookup("Tail")
+	assert.Equal(t, "Sheet1", tail.sheet.Sheet.Properties.Title)
+	assert.Equal(t, &sheets.GridRange{StartRowIndex: 3, EndRowIndex: Unbounded, EndColumnIndex: Unbounded}, tail.gridRange)
+	assert.Nil(t, db.NamedRangeLookup("Gone"))
+	assert.Nil(t, db.NamedRangeLookup("Missing"))
+
+	// the cached range keeps the API form
+	assert.Equal(t, int64(0), db.findNamedRange("Amounts").Range.EndRowIndex)
+}
+
+func TestGridRangeFromAPI(t *testing.T) {
+	api := &sheets.GridRange{SheetId: 5, StartRowIndex: 2, StartColumnIndex: 1, EndColumnIndex: 4}
+	gridRange := gridRangeFromAPI(api)
+	assert.Equal(t, Unbounded, gridRange.EndRowIndex)
+	assert.Equal(t, int64(4), gridRange.EndColumnIndex)
+	assert.Equal(t, int64(0), api.EndRowIndex)
+	assert.Equal(t, api, apiGridRange(gridRange))
+
+	gridRange = gridRangeFromAPI(&sheets.GridRange{})
+	assert.Equal(t, Unbounded, gridRange.EndRowIndex)
+	assert.Equal(t, Unbounded, gridRange.EndColumnIndex)
+}
+
+func TestNamedRangeQueued(t *testing.T) {
+	db := testNamedRanges()
+	upd := db.NewUpdater()
+	dbrange := db.NamedRangeLookup("Flag")
+	assert.ErrorIs(t, upd.AddNamedRange("Flag", dbrange), ErrDuplicateNamedRange)
+	assert.NoError(t, upd.AddNamedRange("New", dbrange))
+	assert.ErrorIs(t, upd.AddNamedRange("New", dbrange), ErrDuplicateNamedRange)
+	assert.ErrorIs(t, upd.RenameNamedRange("Flag", "New"), ErrDuplicateNamedRange)
+	assert.NoError(t, upd.RenameNamedRange("Flag", "Renamed"))
+	assert.ErrorIs(t, upd.AddNamedRange("Renamed", dbrange), ErrDuplicateNamedRange)
+	assert.ErrorIs(t, upd.RenameNamedRange("Amounts", "Renamed"), ErrDuplicateNamedRange)
+	assert.Len(t, upd.requestQueue, 2)
+
+	// names are case insensitive, and free again once deleted or renamed
+	// away in the same batch
+	assert.ErrorIs(t, upd.AddNamedRange("amounts", dbrange), ErrDuplicateNamedRange)
+	assert.ErrorIs(t, upd.AddNamedRange("RENAMED", dbrange), ErrDuplicateNamedRange)
+	assert.NoError(t, upd.AddNamedRange("flag", dbrange))
+	assert.NoError(t, upd.DeleteNamedRange("Amounts"))
+	assert.NoError(t, upd.AddNamedRange("Amounts", dbrange))
+	assert.NoError(t, upd.RenameNamedRange("tail", "TAIL"))
+	assert.Len(t, upd.requestQueue, 6)
+}
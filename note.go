@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"google.golang.org/api/sheets/v4"
+)
+
+// Note returns the note attached to the cell, or "".
+func (cell *Cell) Note() string {
+	if cell == nil || cell.Cell == nil {
+		return ""
+	}
+	return cell.Cell.Note
+}
+
+// SetNote attaches text as the note of every cell in dbrange, replacing any
+// note already there. An empty text removes the notes. The cell values are
+// not touched.
+func (upd *Updater) SetNote(dbrange *DBRange, text string) {
+	upd.queueRequest(&sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range: apiGridRange(dbrange.gridRange),
+			Cell: &sheets.CellData{
+				Note: text,
+			},
+			Fields: "note",
+		},
+	}, func(reply *sheets.Response) {
+		if text == "" {
+			dbrange.sheet.rangeIter(dbrange, func(row, col int64, cell *sheets.CellData) {
+				if cell != nil {
+					cell.Note = ""
+				}
+			})
+			return
+		}
+		// Blank cells may not be in the cache yet; a note makes them real.
+		bounded := dbrange.bounded().gridRange
+		if dbrange.sheet.DB.ensureSheetCapacity(dbrange.sheet, bounded) != nil {
+			return
+		}
+		rows := dbrange.sheet.Sheet.Data[0].RowData
+		for r := bounded.StartRowIndex; r < bounded.EndRowIndex; r++ {
+			for c := bounded.StartColumnIndex; c < bounded.EndColumnIndex; c++ {
+				if rows[r].Values[c] == nil {
+					rows[r].Values[c] = &sheets.CellData{}
+				}
+				rows[r].Values[c].Note = text
+			}
+		}
+	})
+}
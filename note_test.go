@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetNote(t *testing.T) {
+	dbrange := testRange(t, "B2:C3")
+	upd := &Updater{}
+	upd.SetNote(dbrange, "set by bot on 2026-10-01")
+	assert.Len(t, upd.requestQueue, 1)
+	assert.Equal(t, "note", upd.requestQueue[0].request.RepeatCell.Fields)
+
+	// apply runs after the batch succeeds
+	upd.requestQueue[0].apply(nil)
+	sheet := dbrange.sheet
+	assert.Equal(t, "set by bot on 2026-10-01", sheet.GetRowN(2).GetCellN(2).Note())
+	assert.Equal(t, "", sheet.GetRowN(0).GetCellN(0).Note())
+
+	upd.SetNote(dbrange.Offset(1, 1).Resize(1, 1), "")
+	upd.requestQueue[1].apply(nil)
+	assert.Equal(t, "", sheet.GetRowN(2).GetCellN(2).Note())
+	assert.Equal(t, "set by bot on 2026-10-01", sheet.GetRowN(1).GetCellN(1).Note())
+}
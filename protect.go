@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"errors"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var ErrProtectedRangeNotFound = errors.New("protected range not found")
+
+// Protection describes how a range is locked. WarningOnly ranges can still
+// be edited after a confirmation prompt; otherwise only Editors (and the
+// spreadsheet owner) may edit.
+type Protection struct {
+	Description string
+	WarningOnly bool
+	Editors     []string // user email addresses
+	Groups      []string // group email addresses
+}
+
+func (prot *Protection) protectedRange(gridRange *sheets.GridRange) *sheets.ProtectedRange {
+	pr := &sheets.ProtectedRange{
+		Range:       gridRange,
+		Description: prot.Description,
+		WarningOnly: prot.WarningOnly,
+	}
+	if !prot.WarningOnly {
+		pr.Editors = &sheets.Editors{
+			Users:  prot.Editors,
+			Groups: prot.Groups,
+		}
+	}
+	return pr
+}
+
+// ProtectedRanges returns the cached protected ranges of the sheet,
+// including whole-sheet protection.
+func (sheet *Sheet) ProtectedRanges() []*sheets.ProtectedRange {
+	return sheet.Sheet.ProtectedRanges
+}
+
+// protectedGridRange returns the cells pr covers: its Range, or the range
+// of the named range it is defined by. It is nil if neither is cached.
+func (sheet *Sheet) protectedGridRange(pr *sheets.ProtectedRange) *sheets.GridRange {
+	if pr.Range != nil || pr.NamedRangeId == "" || sheet.DB == nil {
+		return pr.Range
+	}
+	if nr := sheet.DB.findNamedRangeByID(pr.NamedRangeId); nr != nil {
+		return nr.Range
+	}
+	return nil
+}
+
+// protects reports whether pr covers any cell of gridRange. A range that
+// lies entirely inside one of pr's UnprotectedRanges is left open, as in a
+// sheet that is protected except for its data area.
+func (sheet *Sheet) protects(pr *sheets.ProtectedRange, gridRange *sheets.GridRange) bool {
+	rng := sheet.protectedGridRange(pr)
+	if rng == nil || !gridRangesOverlap(rng, gridRange) {
+		return false
+	}
+	for _, open := range pr.UnprotectedRanges {
+		if gridRangeContains(open, gridRange) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsProtected reports whether the whole sheet is protected.
+func (sheet *Sheet) IsProtected() bool {
+	whole := &sheets.GridRange{SheetId: sheet.GetID()}
+	for _, pr := range sheet.Sheet.ProtectedRanges {
+		rng := sheet.protectedGridRange(pr)
+		if rng != nil && rng.StartRowIndex == 0 && rng.EndRowIndex == 0 &&
+			rng.StartColumnIndex == 0 && rng.EndColumnIndex == 0 && sheet.protects(pr, whole) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProtectedRanges returns the protected ranges that cover any cell of
+// dbrange, leaving out those that leave all of it unprotected.
+func (dbrange *DBRange) ProtectedRanges() (res []*sheets.ProtectedRange) {
+	for _, pr := range dbrange.sheet.Sheet.ProtectedRanges {
+		if dbrange.sheet.protects(pr, dbrange.gridRange) {
+			res = append(res, pr)
+		}
+	}
+	return //
+}
+
+// IsProtected reports whether the cell is covered by a protected range.
+func (cell *Cell) IsProtected() bool {
+	if cell == nil {
+		return false
+	}
+	return len(cell.Range().ProtectedRanges()) > 0
+}
+
+// Protect locks dbrange.
+func (upd *Updater) Protect(dbrange *DBRange, prot *Protection) {
+	gridRange := apiGridRange(dbrange.gridRange)
+	upd.addProtectedRange(dbrange.sheet, prot.protectedRange(gridRange))
+}
+
+// ProtectSheet locks the whole sheet.
+func (upd *Updater) ProtectSheet(sheet *Sheet, prot *Protection) {
+	upd.addProtectedRange(sheet, prot.protectedRange(&sheets.GridRange{
+		SheetId: sheet.GetID(),
+	}))
+}
+
+func (upd *Updater) addProtectedRange(sheet *Sheet, pr *sheets.ProtectedRange) {
+	upd.queueRequest(&sheets.Request{
+		AddProtectedRange: &sheets.AddProtectedRangeRequest{
+			ProtectedRange: pr,
+		},
+	}, func(reply *sheets.Response) {
+		if reply != nil && reply.AddProtectedRange != nil && reply.AddProtectedRange.ProtectedRange != nil {
+			pr = reply.AddProtectedRange.ProtectedRange
+		}
+		sheet.Sheet.ProtectedRanges = append(sheet.Sheet.ProtectedRanges, pr)
+	})
+}
+
+// UpdateProtection changes the description, mode and editors of the
+// protected range with the given ID.
+func (upd *Updater) UpdateProtection(protectedRangeID int64, prot *Protection) (err error) {
+	sheet, idx := upd.ssdbHandle.findProtectedRange(protectedRangeID)
+	if sheet == nil {
+		return ErrProtectedRangeNotFound
+	}
+	old := sheet.Sheet.ProtectedRanges[idx]
+	pr := prot.protectedRange(old.Range)
+	pr.ProtectedRangeId = protectedRangeID
+	pr.ForceSendFields = []string{"WarningOnly"}
+	fields := "description,warningOnly"
+	if !prot.WarningOnly {
+		fields += ",editors"
+	}
+	upd.queueRequest(&sheets.Request{
+		UpdateProtectedRange: &sheets.UpdateProtectedRangeRequest{
+			ProtectedRange: pr,
+			Fields:         fields,
+		},
+	}, func(reply *sheets.Response) {
+		old.Description = pr.Description
+		old.WarningOnly = pr.WarningOnly
+		old.Editors = pr.Editors
+	})
+	return nil
+}
+
+// MoveProtection changes the cells covered by a protected range.
+func (upd *Updater) MoveProtection(protectedRangeID int64, dbrange *DBRange) (err error) {
+	sheet, idx := upd.ssdbHandle.findProtectedRange(protectedRangeID)
+	if sheet == nil {
+		return ErrProtectedRangeNotFound
+	}
+	old := sheet.Sheet.ProtectedRanges[idx]
+	gridRange := apiGridRange(dbrange.gridRange)
+	upd.queueRequest(&sheets.Request{
+		UpdateProtectedRange: &sheets.UpdateProtectedRangeRequest{
+			ProtectedRange: &sheets.ProtectedRange{
+				ProtectedRangeId: protectedRangeID,
+				Range:            gridRange,
+			},
+			Fields: "range",
+		},
+	}, func(reply *sheets.Response) {
+		old.Range = gridRange
+	})
+	return nil
+}
+
+// Unprotect removes the protected range with the given ID.
+func (upd *Updater) Unprotect(protectedRangeID int64) (err error) {
+	sheet, _ := upd.ssdbHandle.findProtectedRange(protectedRangeID)
+	if sheet == nil {
+		return ErrProtectedRangeNotFound
+	}
+	upd.queueRequest(&sheets.Request{
+		DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{
+			ProtectedRangeId: protectedRangeID,
+		},
+	}, func(reply *sheets.Response) {
+		for i, pr := range sheet.Sheet.ProtectedRanges {
+			if pr.ProtectedRangeId == protectedRangeID {
+				sheet.Sheet.ProtectedRanges = append(sheet.Sheet.ProtectedRanges[:i], sheet.Sheet.ProtectedRanges[i+1:]...)
+				break
+			}
+		}
+	})
+	return nil
+}
+
+func (db *SSDB) findProtectedRange(protectedRangeID int64) (foundSheet *Sheet, idx int) {
+	db.SheetIter(func(sheetname string, sheet *Sheet) {
+		for i, pr := range sheet.Sheet.ProtectedRanges {
+			if pr.ProtectedRangeId == protectedRangeID {
+				foundSheet, idx = sheet, i
+			}
+		}
+	})
+	return //
+}
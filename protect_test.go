@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestProtectedRanges(t *testing.T) {
+	db := testNamedRanges()
+	db.spreadsheet.Sheets[0].ProtectedRanges = []*sheets.ProtectedRange{
+		{ProtectedRangeId: 3, Range: &sheets.GridRange{}},
+	}
+	db.spreadsheet.Sheets[1].ProtectedRanges = []*sheets.ProtectedRange{
+		{ProtectedRangeId: 1, Range: &sheets.GridRange{SheetId: 5, StartRowIndex: 1, EndRowIndex: 3, StartColumnIndex: 0, EndColumnIndex: 2}},
+		{ProtectedRangeId: 2, NamedRangeId: "a"}, // Amounts, Data!C:C
+		{ProtectedRangeId: 4, NamedRangeId: "missing"},
+	}
+	ids := func(a1 string) (res []int64) {
+		for _, pr := range db.NewDBRangeFromSymbolicRange(a1).ProtectedRanges() {
+			res = append(res, pr.ProtectedRangeId)
+		}
+		return //
+	}
+
+	assert.True(t, db.SheetLookup("Sheet1").IsProtected())
+	assert.False(t, db.SheetLookup("Data").IsProtected())
+	assert.Equal(t, []int64{3}, ids("Sheet1!Z900"))
+	assert.Equal(t, []int64{1}, ids("Data!B3"))
+	assert.Equal(t, []int64{2}, ids("Data!C1"))
+	assert.Equal(t, []int64{1, 2}, ids("Data!A1:D2"))
+	assert.Nil(t, ids("Data!A1:B1"))
+	assert.Nil(t, ids("Data!A4:B9"))
+	assert.Nil(t, ids("Data!D:D"))
+
+	// the sheet protected except for A2:B, its data area
+	db.spreadsheet.Sheets[0].ProtectedRanges[0].UnprotectedRanges = []*sheets.GridRange{
+		{StartRowIndex: 1, StartColumnIndex: 0, EndColumnIndex: 2},
+	}
+	assert.True(t, db.SheetLookup("Sheet1").IsProtected())
+	assert.Nil(t, ids("Sheet1!A2"))
+	assert.Nil(t, ids("Sheet1!A5:B900"))
+	assert.Equal(t, []int64{3}, ids("Sheet1!A1"))    // the header row
+	assert.Equal(t, []int64{3}, ids("Sheet1!B2:C3")) // partly open
+	assert.Equal(t, []int64{3}, ids("Sheet1!B:B"))   // B1 is protected
+}
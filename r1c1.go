@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ParseR1C1 parses an R1C1 reference: R1C1, R2C3:R5C8, R5:R10 (full rows),
+// C2:C5 (full columns), Sheet1!R1C1 and 'My Sheet'!R1C1 are accepted, as
+// are relative forms such as R[-1]C[2], which are resolved against the
+// zero based anchor cell. A bare R or C means the anchor's row or column.
+//
+// The result is the same as ParseA1 gives for the equivalent A1 reference.
+func ParseR1C1(r1c1 string, anchorRow, anchorCol int64) (sheetName string, gridRange *sheets.GridRange, err error) {
+	sheetName, rest, err := splitA1Sheet(r1c1)
+	if err != nil {
+		return "", nil, err
+	}
+	spl := strings.Split(rest, ":")
+	if len(spl) > 2 {
+		return "", nil, ErrBadA1
+	}
+	refs := []a1Ref{}
+	for _, part := range spl {
+		ref, err := parseR1C1Ref(part, anchorRow, anchorCol)
+		if err != nil {
+			return "", nil, err
+		}
+		refs = append(refs, ref)
+	}
+	start, end := refs[0], refs[len(refs)-1]
+	startRow, endRow := a1Span(start.row, start.hasRow, end.row, end.hasRow)
+	startCol, endCol := a1Span(start.col, start.hasCol, end.col, end.hasCol)
+	gridRange = &sheets.GridRange{
+		StartRowIndex:    startRow,
+		EndRowIndex:      endRow,
+		StartColumnIndex: startCol,
+		EndColumnIndex:   endCol,
+	}
+	return sheetName, gridRange, nil
+}
+
+// parseR1C1Ref parses one side of an R1C1 range into zero based
+// coordinates.
+func parseR1C1Ref(ref string, anchorRow, anchorCol int64) (res a1Ref, err error) {
+	rest := ref
+	if len(rest) > 0 && (rest[0] == 'R' || rest[0] == 'r') {
+		res.row, rest, err = parseR1C1Index(rest[1:], anchorRow)
+		if err != nil {
+			return res, err
+		}
+		res.hasRow = true
+	}
+	if len(rest) > 0 && (rest[0] == 'C' || rest[0] == 'c') {
+		res.col, rest, err = parseR1C1Index(rest[1:], anchorCol)
+		if err != nil {
+			return res, err
+		}
+		res.hasCol = true
+	}
+	if rest != "" || (!res.hasRow && !res.hasCol) {
+		return res, ErrBadA1
+	}
+	return res, nil
+}
+
+// parseR1C1Index parses what follows an R or C: a one based number, a
+// bracketed offset from anchor, or nothing (the anchor itself).
+func parseR1C1Index(s string, anchor int64) (idx int64, rest string, err error) {
+	switch {
+	case strings.HasPrefix(s, "["):
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return 0, "", ErrBadA1
+		}
+		offset, perr := strconv.ParseInt(s[1:end], 10, 64)
+		if perr != nil {
+			return 0, "", ErrBadA1
+		}
+		idx, rest = anchor+offset, s[end+1:]
+	default:
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return anchor, s, nil
+		}
+		idx, rest = ParseRow(s[:i]), s[i:] // same rules as an A1 row number
+	}
+	if idx < 0 {
+		return 0, "", ErrBadA1
+	}
+	return idx, rest, nil
+}
+
+// FormatR1C1 is the inverse of ParseR1C1, using absolute references.
+func FormatR1C1(sheetName string, gridRange *sheets.GridRange) string {
+	return formatR1C1(sheetName, gridRange, nil)
+}
+
+// FormatR1C1Relative formats gridRange with references relative to the
+// zero based anchor cell, e.g. R[-1]C[2].
+func FormatR1C1Relative(sheetName string, gridRange *sheets.GridRange, anchorRow, anchorCol int64) string {
+	return formatR1C1(sheetName, gridRange, []int64{anchorRow, anchorCol})
+}
+
+func formatR1C1(sheetName string, gridRange *sheets.GridRange, anchor []int64) (r1c1 string) {
+	if sheetName != "" {
+		r1c1 = QuoteSheetName(sheetName) + "!"
+	}
+	index := func(prefix string, idx int64, axis int) string {
+		switch {
+		case anchor == nil:
+			return prefix + strconv.FormatInt(idx+1, 10)
+		case idx == anchor[axis]:
+			return prefix
+		default:
+			return prefix + "[" + strconv.FormatInt(idx-anchor[axis], 10) + "]"
+		}
+	}
+	row := func(idx int64) string { return index("R", idx, 0) }
+	col := func(idx int64) string { return index("C", idx, 1) }
+
+	r0, r1 := gridRange.StartRowIndex, gridRange.EndRowIndex
+	c0, c1 := gridRange.StartColumnIndex, gridRange.EndColumnIndex
+	switch {
+	case r1 == Unbounded && c1 == Unbounded && r0 == 0 && c0 == 0:
+		return strings.TrimSuffix(r1c1, "!")
+	case c1 == Unbounded && c0 == 0 && r1 != Unbounded:
+		return r1c1 + row(r0) + ":" + row(r1-1)
+	case r1 == Unbounded && r0 == 0 && c1 != Unbounded:
+		return r1c1 + col(c0) + ":" + col(c1-1)
+	case r1 == r0+1 && c1 == c0+1:
+		return r1c1 + row(r0) + col(c0)
+	}
+	end := ""
+	if r1 != Unbounded {
+		end += row(r1 - 1)
+	}
+	switch {
+	case c1 != Unbounded:
+		end += col(c1 - 1)
+	case r1 == Unbounded:
+		end += col(lastA1Column) // see FormatA1
+	}
+	return r1c1 + row(r0) + col(c0) + ":" + end
+}
+
+// NewDBRangeFromR1C1 is NewDBRangeFromSymbolicRange for R1C1 notation.
+// Relative references are resolved against the zero based anchor cell.
+func (ssdb *SSDB) NewDBRangeFromR1C1(r1c1 string, anchorRow, anchorCol int64) (dbRange *DBRange) {
+	sheetMatch, gridRange, err := ParseR1C1(r1c1, anchorRow, anchorCol)
+	if err != nil || sheetMatch == "" {
+		return nil
+	}
+	foundSheet := ssdb.SheetLookup(sheetMatch)
+	if foundSheet == nil {
+		return nil
+	}
+	gridRange.SheetId = foundSheet.GetID()
+	dbRange = &DBRange{
+		gridRange: gridRange,
+		sheet:     foundSheet,
+	}
+	dbRange.symbolicRange = dbRange.String()
+	return //
+}
+
+// A1 formats dbrange in A1 notation; it is the same as String.
+func (dbrange *DBRange) A1() string {
+	return dbrange.String()
+}
+
+// R1C1 formats dbrange in absolute R1C1 notation.
+func (dbrange *DBRange) R1C1() string {
+	return FormatR1C1(dbrange.sheet.Sheet.Properties.Title, dbrange.gridRange)
+}
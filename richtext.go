@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// TextRun is a piece of a cell's text with its own format. Format is nil
+// where the text uses the cell's format.
+type TextRun struct {
+	Text   string
+	Format *sheets.TextFormat
+}
+
+// RichText is a value written as text with formatted runs, e.g. a
+// partially bold note:
+//
+//	ssdb.RichText{{Text: "Paid: "}, {Text: "yes", Format: &sheets.TextFormat{Bold: true}}}
+type RichText []TextRun
+
+// Link is a value written as text that links to URL.
+type Link struct {
+	Text string
+	URL  string
+}
+
+// Hyperlink returns the URL the cell links to, or "". A cell with several
+// links returns the first.
+func (cell *Cell) Hyperlink() string {
+	if cell == nil || cell.Cell == nil {
+		return ""
+	}
+	if cell.Cell.Hyperlink != "" {
+		return cell.Cell.Hyperlink
+	}
+	if f := cell.Cell.UserEnteredFormat; f != nil && f.TextFormat != nil && f.TextFormat.Link != nil {
+		return f.TextFormat.Link.Uri
+	}
+	for _, run := range cell.Cell.TextFormatRuns {
+		if run.Format != nil && run.Format.Link != nil {
+			return run.Format.Link.Uri
+		}
+	}
+	return ""
+}
+
+// TextRuns splits the cell's text by format. A cell without formatted runs
+// is a single run with a nil Format; a blank cell has no runs.
+func (cell *Cell) TextRuns() (runs RichText) {
+	if cell == nil || cell.Cell == nil {
+		return nil
+	}
+	text := GetCellDataString(cell.Cell)
+	if len(cell.Cell.TextFormatRuns) == 0 {
+		if text == "" {
+			return nil
+		}
+		return RichText{{Text: text}}
+	}
+	// run indexes count UTF-16 code units
+	units := utf16.Encode([]rune(text))
+	clip := func(i int64) int64 {
+		return min(max(i, 0), int64(len(units)))
+	}
+	if first := clip(cell.Cell.TextFormatRuns[0].StartIndex); first > 0 {
+		runs = append(runs, TextRun{Text: string(utf16.Decode(units[:first]))})
+	}
+	for i, run := range cell.Cell.TextFormatRuns {
+		start, end := clip(run.StartIndex), int64(len(units))
+		if i+1 < len(cell.Cell.TextFormatRuns) {
+			end = clip(cell.Cell.TextFormatRuns[i+1].StartIndex)
+		}
+		if start >= end {
+			continue
+		}
+		runs = append(runs, TextRun{
+			Text:   string(utf16.Decode(units[start:end])),
+			Format: run.Format,
+		})
+	}
+	return //
+}
+
+// String returns the plain text of rt.
+func (rt RichText) String() string {
+	var b strings.Builder
+	for _, run := range rt {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+func (rt RichText) encode(cell *sheets.CellData) {
+	text := rt.String()
+	cell.UserEnteredValue = &sheets.ExtendedValue{StringValue: &text}
+	cell.TextFormatRuns = nil
+	var start int64
+	for _, run := range rt {
+		format := run.Format
+		if format == nil {
+			format = &sheets.TextFormat{}
+		}
+		cell.TextFormatRuns = append(cell.TextFormatRuns, &sheets.TextFormatRun{
+			StartIndex: start,
+			Format:     format,
+		})
+		start += int64(len(utf16.Encode([]rune(run.Text))))
+	}
+}
+
+func (link Link) encode(cell *sheets.CellData) {
+	text := link.Text
+	if text == "" {
+		text = link.URL
+	}
+	RichText{{Text: text, Format: &sheets.TextFormat{Link: &sheets.Link{Uri: link.URL}}}}.encode(cell)
+	cell.Hyperlink = link.URL
+}
+
+// RowdataFields returns the field mask for writing rowData with an
+// UpdateCellsRequest. Text runs are only in the mask if some cell has
+// them, so plain writes leave the rest of the cell's formatting alone.
+func RowdataFields(rowData []*sheets.RowData) string {
+	for _, row := range rowData {
+		for _, cell := range row.Values {
+			if cell != nil && len(cell.TextFormatRuns) > 0 {
+				return "userEnteredValue,userEnteredFormat.backgroundColor,textFormatRuns"
+			}
+		}
+	}
+	return "userEnteredValue,userEnteredFormat.backgroundColor"
+}
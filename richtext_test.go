@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestRichText(t *testing.T) {
+	bold := &sheets.TextFormat{Bold: true}
+	rt := RichText{{Text: "Paid 💶 "}, {Text: "yes", Format: bold}}
+	rowData := BuildRowdataAny([][]any{{rt, Link{Text: "Home", URL: "https://example.org"}, "plain"}})
+	assert.Equal(t, "userEnteredValue,userEnteredFormat.backgroundColor,textFormatRuns", RowdataFields(rowData))
+	assert.Equal(t, "userEnteredValue,userEnteredFormat.backgroundColor", RowdataFields(BuildRowdataAny([][]any{{"plain"}})))
+
+	written := rowData[0].Values[0]
+	assert.Equal(t, "Paid 💶 yes", *written.UserEnteredValue.StringValue)
+	assert.Len(t, written.TextFormatRuns, 2)
+	// the emoji is two UTF-16 code units
+	assert.Equal(t, int64(8), written.TextFormatRuns[1].StartIndex)
+
+	cell := &Cell{Cell: written}
+	runs := cell.TextRuns()
+	assert.Equal(t, "yes", runs[1].Text)
+	assert.Equal(t, bold, runs[1].Format)
+	assert.Equal(t, rt.String(), runs.String())
+	assert.Equal(t, "", cell.Hyperlink())
+
+	link := &Cell{Cell: rowData[0].Values[1]}
+	assert.Equal(t, "https://example.org", link.Hyperlink())
+	assert.Equal(t, "Home", link.GetString())
+	assert.Equal(t, RichText{{Text: "plain"}}, (&Cell{Cell: rowData[0].Values[2]}).TextRuns())
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// RowIDKey is the developer metadata key used to tag rows with a stable ID.
+// The metadata follows the row when it is sorted or moved in the UI.
+const RowIDKey = "ssdb.rowid"
+
+var ErrRowIDNotFound = errors.New("row id not found")
+var ErrDuplicateRowID = errors.New("duplicate row id")
+
+// NewRowID returns a random ID suitable for TagRow.
+func NewRowID() string {
+	buf := make([]byte, 12)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// TagRow attaches id to row N of sheet as hidden developer metadata.
+func (upd *Updater) TagRow(sheet *Sheet, N int64, id string) {
+	md := &sheets.DeveloperMetadata{
+		MetadataKey:   RowIDKey,
+		MetadataValue: id,
+		Visibility:    "DOCUMENT",
+		Location: &sheets.DeveloperMetadataLocation{
+			DimensionRange: &sheets.DimensionRange{
+				SheetId:    sheet.GetID(),
+				Dimension:  "ROWS",
+				StartIndex: N,
+				EndIndex:   N + 1,
+			},
+		},
+	}
+	upd.queueRequest(&sheets.Request{
+		CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{
+			DeveloperMetadata: md,
+		},
+	}, func(reply *sheets.Response) {
+		if reply != nil && reply.CreateDeveloperMetadata != nil && reply.CreateDeveloperMetadata.DeveloperMetadata != nil {
+			md = reply.CreateDeveloperMetadata.DeveloperMetadata
+		}
+		props := sheet.rowMetadata(N)
+		props.DeveloperMetadata = append(props.DeveloperMetadata, md)
+	})
+}
+
+// UpdateTagged is Update for newly created rows: each row of dbrange is
+// tagged with the matching entry of ids.
+func (upd *Updater) UpdateTagged(dbrange *DBRange, newvals [][]any, ids []string) {
+	upd.Update(dbrange, newvals)
+	for i, id := range ids {
+		upd.TagRow(dbrange.sheet, dbrange.gridRange.StartRowIndex+int64(i), id)
+	}
+}
+
+// UpdateByID queues an update of the row tagged with id, starting at
+// column col. The row is located on the server at Sync time, so the write
+// lands on the record even if the sheet was re-sorted after the load.
+func (upd *Updater) UpdateByID(sheet *Sheet, id string, col int64, newvals []any) (err error) {
+	row := sheet.RowByID(id)
+	if row == nil {
+		return ErrRowIDNotFound
+	}
+	dbrange := upd.ssdbHandle.NewDBRange(sheet.Sheet.Properties.Title, row.N, col, 1, int64(len(newvals)))
+	updtItem := &updateItem{
+		dbRange: dbrange,
+		olddata: sheet.CopyVals(dbrange),
+		newdata: [][]any{newvals},
+		rowID:   id,
+	}
+	upd.Lock()
+	upd.updateQueue = append(upd.updateQueue, updtItem)
+	upd.Unlock()
+	return nil
+}
+
+// ID returns the row's stable ID from the cache, or "" if it isn't tagged.
+func (row *Row) ID() string {
+	gridData := row.Sheet.Sheet.Data
+	if len(gridData) == 0 || int(row.N) >= len(gridData[0].RowMetadata) {
+		return ""
+	}
+	props := gridData[0].RowMetadata[row.N]
+	if props == nil {
+		return ""
+	}
+	for _, md := range props.DeveloperMetadata {
+		if md.MetadataKey == RowIDKey {
+			return md.MetadataValue
+		}
+	}
+	return ""
+}
+
+// RowByID finds the cached row tagged with id.
+func (sheet *Sheet) RowByID(id string) (foundRow *Row) {
+	sheet.RowIter(func(row *Row) {
+		if foundRow == nil && row.ID() == id {
+			foundRow = row
+		}
+	})
+	return //
+}
+
+// LocateRowIDs asks the server where the rows of sheet tagged with ids
+// currently are. The result maps id to zero based row number.
+func (db *SSDB) LocateRowIDs(sheet *Sheet, ids ...string) (rows map[string]int64, err error) {
+	sheetID := sheet.GetID()
+	req := &sheets.SearchDeveloperMetadataRequest{}
+	for _, id := range ids {
+		req.DataFilters = append(req.DataFilters, &sheets.DataFilter{
+			DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{
+				MetadataKey:   RowIDKey,
+				MetadataValue: id,
+				LocationType:  "ROW",
+				MetadataLocation: &sheets.DeveloperMetadataLocation{
+					SheetId:         sheetID,
+					ForceSendFields: []string{"SheetId"}, // sheet 0 is a sheet too
+				},
+				LocationMatchingStrategy: "INTERSECTING_LOCATION",
+			},
+		})
+	}
+	resp, err := db.SheetsService.Spreadsheets.DeveloperMetadata.Search(db.SpreadsheetID, req).Context(db.ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to search developer metadata: %w", err)
+	}
+	rows = make(map[string]int64)
+	for _, match := range resp.MatchedDeveloperMetadata {
+		md := match.DeveloperMetadata
+		if md == nil || md.Location == nil || md.Location.DimensionRange == nil || md.Location.DimensionRange.SheetId != sheetID {
+			continue
+		}
+		if _, dup := rows[md.MetadataValue]; dup {
+			return nil, fmt.Errorf("%s: %w", md.MetadataValue, ErrDuplicateRowID)
+		}
+		rows[md.MetadataValue] = md.Location.DimensionRange.StartIndex
+	}
+	return //
+}
+
+// resolveRowIDs points ID based updates at the rows' current positions.
+// It reports whether any of them moved, in which case the cache no longer
+// matches the sheet's layout.
+func (upd *Updater) resolveRowIDs() (moved bool, err error) {
+	ids := map[*sheets.Sheet][]string{}
+	sheetOf := map[*sheets.Sheet]*Sheet{}
+	for _, elem := range upd.updateQueue {
+		if elem.rowID != "" {
+			sheet := elem.dbRange.sheet
+			ids[sheet.Sheet] = append(ids[sheet.Sheet], elem.rowID)
+			sheetOf[sheet.Sheet] = sheet
+		}
+	}
+	rows := map[*sheets.Sheet]map[string]int64{}
+	for key, sheetIDs := range ids {
+		if rows[key], err = upd.ssdbHandle.LocateRowIDs(sheetOf[key], sheetIDs...); err != nil {
+			return false, err
+		}
+	}
+	for _, elem := range upd.updateQueue {
+		if elem.rowID == "" {
+			continue
+		}
+		N, ok := rows[elem.dbRange.sheet.Sheet][elem.rowID]
+		if !ok {
+			return false, fmt.Errorf("%s: %w", elem.rowID, ErrRowIDNotFound)
+		}
+		if N == elem.dbRange.gridRange.StartRowIndex {
+			continue
+		}
+		gridRange := *elem.dbRange.gridRange
+		gridRange.StartRowIndex, gridRange.EndRowIndex = N, N+1
+		elem.dbRange = &DBRange{
+			gridRange: &gridRange,
+			sheet:     elem.dbRange.sheet,
+		}
+		elem.dbRange.symbolicRange = elem.dbRange.String()
+		moved = true
+	}
+	return //
+}
+
+// rebind points the queued updates at the sheets of a freshly loaded
+// cache.
+func (upd *Updater) rebind() (err error) {
+	for _, elem := range upd.updateQueue {
+		title := elem.dbRange.sheet.Sheet.Properties.Title
+		sheet := upd.ssdbHandle.SheetLookup(title)
+		if sheet == nil {
+			return fmt.Errorf("%s: sheet is gone: %w", title, ErrBadRange)
+		}
+		dbrange := *elem.dbRange
+		dbrange.sheet = sheet
+		elem.dbRange = &dbrange
+	}
+	return nil
+}
+
+// rowMetadata returns the cached dimension properties of row N, creating
+// them if needed.
+func (sheet *Sheet) rowMetadata(N int64) *sheets.DimensionProperties {
+	if len(sheet.Sheet.Data) == 0 {
+		sheet.Sheet.Data = []*sheets.GridData{{}}
+	}
+	gridData := sheet.Sheet.Data[0]
+	for int64(len(gridData.RowMetadata)) <= N {
+		gridData.RowMetadata = append(gridData.RowMetadata, &sheets.DimensionProperties{})
+	}
+	if gridData.RowMetadata[N] == nil {
+		gridData.RowMetadata[N] = &sheets.DimensionProperties{}
+	}
+	return gridData.RowMetadata[N]
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestRebind(t *testing.T) {
+	grid := func(val string) []*sheets.GridData {
+		return []*sheets.GridData{{RowData: []*sheets.RowData{{Values: []*sheets.CellData{{FormattedValue: val}}}}}}
+	}
+	db := &SSDB{spreadsheet: &sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+		{Properties: &sheets.SheetProperties{Title: "Sheet1"}, Data: grid("old")},
+	}}}
+	dbrange := db.NewDBRange("Sheet1", 0, 0, 1, 1)
+	upd := db.NewUpdater()
+	upd.Update(dbrange, [][]any{{"new"}})
+
+	// as after Loader
+	db.spreadsheet = &sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+		{Properties: &sheets.SheetProperties{Title: "Sheet1"}, Data: grid("moved")},
+	}}
+	assert.NoError(t, upd.rebind())
+	elem := upd.updateQueue[0]
+	assert.False(t, elem.dbRange.sheet.CompareVals(elem.dbRange, elem.olddata))
+	assert.True(t, elem.dbRange.sheet.CompareVals(elem.dbRange, [][]any{{"moved"}}))
+	assert.Equal(t, "old", dbrange.sheet.GetRowN(0).GetCellN(0).GetString())
+
+	db.spreadsheet = &sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+		{Properties: &sheets.SheetProperties{Title: "Other"}, Data: grid("moved")},
+	}}
+	assert.ErrorIs(t, upd.rebind(), ErrBadRange)
+}
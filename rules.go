@@ -0,0 +1,372 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// DataValidation is a validation rule and the cells it covers.
+type DataValidation struct {
+	Range *DBRange
+	Rule  *sheets.DataValidationRule
+}
+
+// ConditionalFormat is a conditional format rule and its position in the
+// sheet's rule list. Index is what Replace/Delete expect.
+type ConditionalFormat struct {
+	Index int64
+	Rule  *sheets.ConditionalFormatRule
+}
+
+// DataValidations lists the validation rules on the cached cells of
+// dbrange. Vertically adjacent cells that share a rule are reported as one
+// range.
+func (dbrange *DBRange) DataValidations() (res []*DataValidation) {
+	open := map[int64]*DataValidation{} // by column
+	dbrange.sheet.rangeIter(dbrange, func(row, col int64, cell *sheets.CellData) {
+		var rule *sheets.DataValidationRule
+		if cell != nil {
+			rule = cell.DataValidation
+		}
+		cur := open[col]
+		switch {
+		case rule == nil:
+			delete(open, col)
+		case cur != nil && cur.Range.gridRange.EndRowIndex == row && sameRule(cur.Rule, rule):
+			cur.Range.gridRange.EndRowIndex = row + 1
+		default:
+			dv := &DataValidation{
+				Range: &DBRange{
+					gridRange: GenRange(dbrange.gridRange.SheetId, col, col+1, row, row+1),
+					sheet:     dbrange.sheet,
+				},
+				Rule: rule,
+			}
+			open[col] = dv
+			res = append(res, dv)
+		}
+	})
+	for _, dv := range res {
+		dv.Range.symbolicRange = dv.Range.String()
+	}
+	return //
+}
+
+// SetDataValidation adds rule to every cell of dbrange, replacing whatever
+// validation was there.
+func (upd *Updater) SetDataValidation(dbrange *DBRange, rule *sheets.DataValidationRule) {
+	upd.queueRequest(&sheets.Request{
+		SetDataValidation: &sheets.SetDataValidationRequest{
+			Range: apiGridRange(dbrange.gridRange),
+			Rule:  rule,
+		},
+	}, func(reply *sheets.Response) {
+		dbrange.sheet.rangeIter(dbrange, func(row, col int64, cell *sheets.CellData) {
+			if cell != nil {
+				cell.DataValidation = rule
+			}
+		})
+	})
+}
+
+// ClearDataValidation removes validation from every cell of dbrange.
+func (upd *Updater) ClearDataValidation(dbrange *DBRange) {
+	upd.SetDataValidation(dbrange, nil)
+}
+
+// ConditionalFormats lists the conditional format rules of the sheet that
+// apply to any cell of dbrange.
+func (dbrange *DBRange) ConditionalFormats() (res []*ConditionalFormat) {
+	for i, rule := range dbrange.sheet.Sheet.ConditionalFormats {
+		for _, rng := range rule.Ranges {
+			if gridRangesOverlap(rng, dbrange.gridRange) {
+				res = append(res, &ConditionalFormat{
+					Index: int64(i),
+					Rule:  rule,
+				})
+				break
+			}
+		}
+	}
+	return //
+}
+
+// AddConditionalFormat inserts rule at index in the sheet's rule list.
+// Rules earlier in the list take priority.
+//
+// The index passed to AddConditionalFormat, ReplaceConditionalFormat and
+// DeleteConditionalFormat is a position in the cached list, as
+// ConditionalFormats reports it; it is shifted past the rules already added
+// or deleted earlier in the same batch. Rules added at the same index go in
+// in the order they were queued.
+func (upd *Updater) AddConditionalFormat(sheet *Sheet, index int64, rule *sheets.ConditionalFormatRule) {
+	index, _ = upd.queuedRuleIndex(sheet.GetID(), index)
+	upd.queueRequest(&sheets.Request{
+		AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+			Index: index,
+			Rule:  rule,
+		},
+	}, func(reply *sheets.Response) {
+		rules := sheet.Sheet.ConditionalFormats
+		if index > int64(len(rules)) {
+			index = int64(len(rules))
+		}
+		rules = append(rules, nil)
+		copy(rules[index+1:], rules[index:])
+		rules[index] = rule
+		sheet.Sheet.ConditionalFormats = rules
+	})
+}
+
+// ReplaceConditionalFormat replaces the rule at index. Nothing is queued if
+// the rule was deleted earlier in the batch.
+func (upd *Updater) ReplaceConditionalFormat(sheet *Sheet, index int64, rule *sheets.ConditionalFormatRule) {
+	index, ok := upd.queuedRuleIndex(sheet.GetID(), index)
+	if !ok {
+		return
+	}
+	upd.queueRequest(&sheets.Request{
+		UpdateConditionalFormatRule: &sheets.UpdateConditionalFormatRuleRequest{
+			SheetId: sheet.GetID(),
+			Index:   index,
+			Rule:    rule,
+		},
+	}, func(reply *sheets.Response) {
+		if index < int64(len(sheet.Sheet.ConditionalFormats)) {
+			sheet.Sheet.ConditionalFormats[index] = rule
+		}
+	})
+}
+
+// DeleteConditionalFormat removes the rule at index. Deleting a rule twice
+// in one batch deletes it once.
+func (upd *Updater) DeleteConditionalFormat(sheet *Sheet, index int64) {
+	index, ok := upd.queuedRuleIndex(sheet.GetID(), index)
+	if !ok {
+		return
+	}
+	upd.queueRequest(&sheets.Request{
+		DeleteConditionalFormatRule: &sheets.DeleteConditionalFormatRuleRequest{
+			SheetId: sheet.GetID(),
+			Index:   index,
+		},
+	}, func(reply *sheets.Response) {
+		rules := sheet.Sheet.ConditionalFormats
+		if index < int64(len(rules)) {
+			sheet.Sheet.ConditionalFormats = append(rules[:index], rules[index+1:]...)
+		}
+	})
+}
+
+// queuedRuleIndex maps index, a position in the cached rule list of the
+// sheet, to where that rule will be once the conditional format requests
+// already queued for the sheet have run. ok is false if one of them
+// deletes it.
+func (upd *Updater) queuedRuleIndex(sheetID, index int64) (res int64, ok bool) {
+	upd.Lock()
+	defer upd.Unlock()
+
+	res, ok = index, true
+	for _, item := range upd.requestQueue {
+		switch req := item.request; {
+		case req.AddConditionalFormatRule != nil:
+			add := req.AddConditionalFormatRule
+			if len(add.Rule.Ranges) > 0 && add.Rule.Ranges[0].SheetId == sheetID && add.Index <= res {
+				res++
+			}
+		case req.DeleteConditionalFormatRule != nil:
+			del := req.DeleteConditionalFormatRule
+			switch {
+			case del.SheetId != sheetID || del.Index > res:
+			case del.Index == res:
+				ok = false
+			default:
+				res--
+			}
+		}
+	}
+	return res, ok
+}
+
+// RuleSpec is a declarative description of the validation and highlight
+// rules a spreadsheet should have. It is meant to be kept in code or
+// loaded from JSON and applied at startup.
+type RuleSpec struct {
+	Validations []ValidationSpec `json:"validations,omitempty"`
+	Highlights  []HighlightSpec  `json:"highlights,omitempty"`
+}
+
+// ValidationSpec describes a data validation rule on an A1 range.
+// Either OneOf (a dropdown) or Condition/Values must be given.
+type ValidationSpec struct {
+	Range        string   `json:"range"`
+	OneOf        []string `json:"oneOf,omitempty"`
+	Condition    string   `json:"condition,omitempty"` // e.g. NUMBER_GREATER, DATE_IS_VALID
+	Values       []string `json:"values,omitempty"`
+	Strict       bool     `json:"strict,omitempty"`
+	InputMessage string   `json:"inputMessage,omitempty"`
+}
+
+// HighlightSpec describes a conditional format rule on an A1 range.
+// Formula is shorthand for a CUSTOM_FORMULA condition.
+type HighlightSpec struct {
+	Range      string        `json:"range"`
+	Formula    string        `json:"formula,omitempty"`
+	Condition  string        `json:"condition,omitempty"`
+	Values     []string      `json:"values,omitempty"`
+	Background *sheets.Color `json:"background,omitempty"`
+	TextColor  *sheets.Color `json:"textColor,omitempty"`
+	Bold       bool          `json:"bold,omitempty"`
+}
+
+// Rule builds the validation rule described by spec.
+func (spec *ValidationSpec) Rule() (rule *sheets.DataValidationRule) {
+	cond := &sheets.BooleanCondition{
+		Type:   spec.Condition,
+		Values: conditionValues(spec.Values),
+	}
+	if len(spec.OneOf) > 0 {
+		cond = &sheets.BooleanCondition{
+			Type:   "ONE_OF_LIST",
+			Values: conditionValues(spec.OneOf),
+		}
+	}
+	return &sheets.DataValidationRule{
+		Condition:    cond,
+		Strict:       spec.Strict,
+		ShowCustomUi: len(spec.OneOf) > 0,
+		InputMessage: spec.InputMessage,
+	}
+}
+
+// Rule builds the conditional format rule described by spec over gridRange.
+func (spec *HighlightSpec) Rule(gridRange *sheets.GridRange) (rule *sheets.ConditionalFormatRule) {
+	cond := &sheets.BooleanCondition{
+		Type:   spec.Condition,
+		Values: conditionValues(spec.Values),
+	}
+	if spec.Formula != "" {
+		cond = &sheets.BooleanCondition{
+			Type:   "CUSTOM_FORMULA",
+			Values: conditionValues([]string{spec.Formula}),
+		}
+	}
+	format := &sheets.CellFormat{
+		BackgroundColor: spec.Background,
+	}
+	if spec.Bold || spec.TextColor != nil {
+		format.TextFormat = &sheets.TextFormat{
+			Bold:            spec.Bold,
+			ForegroundColor: spec.TextColor,
+		}
+	}
+	return &sheets.ConditionalFormatRule{
+		Ranges: []*sheets.GridRange{gridRange},
+		BooleanRule: &sheets.BooleanRule{
+			Condition: cond,
+			Format:    format,
+		},
+	}
+}
+
+// ApplyRules queues the requests needed to bring the spreadsheet in line
+// with spec. Validations are always (re)set. Highlights that already exist
+// with the same range and rule are left alone, so applying the same spec
+// twice doesn't stack duplicate rules. Rules not in spec are not touched.
+func (upd *Updater) ApplyRules(spec *RuleSpec) (err error) {
+	for _, vs := range spec.Validations {
+		dbrange := upd.ssdbHandle.NewDBRangeFromSymbolicRange(vs.Range)
+		if dbrange == nil {
+			return fmt.Errorf("validation %q: %w", vs.Range, ErrBadRange)
+		}
+		upd.SetDataValidation(dbrange, vs.Rule())
+	}
+	for _, hs := range spec.Highlights {
+		dbrange := upd.ssdbHandle.NewDBRangeFromSymbolicRange(hs.Range)
+		if dbrange == nil {
+			return fmt.Errorf("highlight %q: %w", hs.Range, ErrBadRange)
+		}
+		rule := hs.Rule(apiGridRange(dbrange.gridRange))
+		found := false
+		for _, existing := range dbrange.sheet.Sheet.ConditionalFormats {
+			if sameRule(existing, rule) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			upd.AddConditionalFormat(dbrange.sheet, int64(len(dbrange.sheet.Sheet.ConditionalFormats)), rule)
+		}
+	}
+	return nil
+}
+
+func conditionValues(vals []string) (res []*sheets.ConditionValue) {
+	for _, v := range vals {
+		res = append(res, &sheets.ConditionValue{UserEnteredValue: v})
+	}
+	return //
+}
+
+// sameRule compares two API objects by their wire form.
+func sameRule(a, b any) bool {
+	ja, erra := json.Marshal(a)
+	jb, errb := json.Marshal(b)
+	return erra == nil && errb == nil && string(ja) == string(jb)
+}
+
+// gridRangesOverlap reports whether a and b share a cell. Either may be in
+// API form, where an end index of zero means unbounded.
+func gridRangesOverlap(a, b *sheets.GridRange) bool {
+	if a.SheetId != b.SheetId {
+		return false
+	}
+	overlap := func(s0, e0, s1, e1 int64) bool {
+		unbounded := func(e int64) bool { return e == 0 || e == Unbounded }
+		return (unbounded(e1) || s0 < e1) && (unbounded(e0) || s1 < e0)
+	}
+	return overlap(a.StartRowIndex, a.EndRowIndex, b.StartRowIndex, b.EndRowIndex) &&
+		overlap(a.StartColumnIndex, a.EndColumnIndex, b.StartColumnIndex, b.EndColumnIndex)
+}
+
+// gridRangeContains reports whether every cell of inner is in outer. Like
+// gridRangesOverlap it takes either form of an unbounded end.
+func gridRangeContains(outer, inner *sheets.GridRange) bool {
+	if outer.SheetId != inner.SheetId {
+		return false
+	}
+	within := func(s0, e0, s1, e1 int64) bool {
+		unbounded := func(e int64) bool { return e == 0 || e == Unbounded }
+		return s0 <= s1 && (unbounded(e0) || !unbounded(e1) && e1 <= e0)
+	}
+	return within(outer.StartRowIndex, outer.EndRowIndex, inner.StartRowIndex, inner.EndRowIndex) &&
+		within(outer.StartColumnIndex, outer.EndColumnIndex, inner.StartColumnIndex, inner.EndColumnIndex)
+}
+
+// rangeIter calls f for every cached cell inside dbrange. Positions past
+// the end of the cached data are skipped.
+func (sheet *Sheet) rangeIter(dbrange *DBRange, f func(row, col int64, cell *sheets.CellData)) {
+	if len(sheet.Sheet.Data) == 0 {
+		return
+	}
+	rows := sheet.Sheet.Data[0].RowData
+	for r := dbrange.gridRange.StartRowIndex; r < endIndex(dbrange.gridRange.EndRowIndex) && r < int64(len(rows)); r++ {
+		if rows[r] == nil {
+			continue
+		}
+		for c := dbrange.gridRange.StartColumnIndex; c < endIndex(dbrange.gridRange.EndColumnIndex); c++ {
+			if c >= int64(len(rows[r].Values)) {
+				break
+			}
+			f(r, c, rows[r].Values[c])
+		}
+	}
+}
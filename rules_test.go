@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestDataValidations(t *testing.T) {
+	dbrange := testRange(t, "A1:B5")
+	yes := &sheets.DataValidationRule{Condition: &sheets.BooleanCondition{Type: "BOOLEAN"}}
+	list := (&ValidationSpec{OneOf: []string{"a", "b"}}).Rule()
+	cell := func(rule *sheets.DataValidationRule) *sheets.CellData {
+		return &sheets.CellData{DataValidation: rule}
+	}
+	dbrange.sheet.Sheet.Data[0].RowData = []*sheets.RowData{
+		{Values: []*sheets.CellData{cell(yes), cell(list)}},
+		{Values: []*sheets.CellData{cell(yes)}},
+		{Values: []*sheets.CellData{cell(yes), cell(yes)}},
+		{Values: []*sheets.CellData{nil, cell(yes)}},
+		{Values: []*sheets.CellData{cell(yes)}},
+	}
+	var got []string
+	for _, dv := range dbrange.DataValidations() {
+		got = append(got, dv.Range.String()+" "+dv.Rule.Condition.Type)
+	}
+	assert.Equal(t, []string{
+		"Sheet1!A1:A3 BOOLEAN",
+		"Sheet1!B1 ONE_OF_LIST",
+		"Sheet1!B3:B4 BOOLEAN",
+		"Sheet1!A5 BOOLEAN",
+	}, got)
+}
+
+func TestRuleSpec(t *testing.T) {
+	vs := &ValidationSpec{Condition: "NUMBER_GREATER", Values: []string{"0"}, Strict: true}
+	rule := vs.Rule()
+	assert.Equal(t, "NUMBER_GREATER", rule.Condition.Type)
+	assert.Equal(t, "0", rule.Condition.Values[0].UserEnteredValue)
+	assert.True(t, rule.Strict)
+	assert.False(t, rule.ShowCustomUi)
+
+	vs = &ValidationSpec{OneOf: []string{"yes", "no"}, Condition: "ignored"}
+	rule = vs.Rule()
+	assert.Equal(t, "ONE_OF_LIST", rule.Condition.Type)
+	assert.Len(t, rule.Condition.Values, 2)
+	assert.True(t, rule.ShowCustomUi)
+
+	gridRange := &sheets.GridRange{SheetId: 7, EndRowIndex: 10, EndColumnIndex: 2}
+	red := &sheets.Color{Red: 1}
+	hs := &HighlightSpec{Formula: "=$B1>10", Background: red}
+	cf := hs.Rule(gridRange)
+	assert.Equal(t, []*sheets.GridRange{gridRange}, cf.Ranges)
+	assert.Equal(t, "CUSTOM_FORMULA", cf.BooleanRule.Condition.Type)
+	assert.Equal(t, "=$B1>10", cf.BooleanRule.Condition.Values[0].UserEnteredValue)
+	assert.Equal(t, red, cf.BooleanRule.Format.BackgroundColor)
+	assert.Nil(t, cf.BooleanRule.Format.TextFormat)
+
+	hs = &HighlightSpec{Condition: "TEXT_CONTAINS", Values: []string{"late"}, Bold: true}
+	cf = hs.Rule(gridRange)
+	assert.Equal(t, "TEXT_CONTAINS", cf.BooleanRule.Condition.Type)
+	assert.True(t, cf.BooleanRule.Format.TextFormat.Bold)
+
+	assert.True(t, sameRule(cf, hs.Rule(&sheets.GridRange{SheetId: 7, EndRowIndex: 10, EndColumnIndex: 2})))
+	assert.False(t, sameRule(cf, hs.Rule(&sheets.GridRange{SheetId: 7, EndRowIndex: 11, EndColumnIndex: 2})))
+	assert.False(t, sameRule(cf, (&HighlightSpec{Condition: "TEXT_CONTAINS", Values: []string{"late"}}).Rule(gridRange)))
+}
+
+func TestConditionalFormatBatch(t *testing.T) {
+	sheet := testRange(t, "A1").sheet
+	rule := func(name string) *sheets.ConditionalFormatRule {
+		return (&HighlightSpec{Formula: name}).Rule(&sheets.GridRange{})
+	}
+	sheet.Sheet.ConditionalFormats = []*sheets.ConditionalFormatRule{rule("a"), rule("b"), rule("c"), rule("d")}
+	names := func() (res string) {
+		for _, cf := range sheet.Sheet.ConditionalFormats {
+			res += cf.BooleanRule.Condition.Values[0].UserEnteredValue
+		}
+		return //
+	}
+
+	upd := &Updater{}
+	upd.DeleteConditionalFormat(sheet, 0)
+	upd.DeleteConditionalFormat(sheet, 2)
+	upd.DeleteConditionalFormat(sheet, 0) // already deleted
+	upd.ReplaceConditionalFormat(sheet, 0, rule("x"))
+	upd.ReplaceConditionalFormat(sheet, 3, rule("D"))
+	upd.AddConditionalFormat(sheet, 1, rule("B"))
+	upd.AddConditionalFormat(sheet, 4, rule("e"))
+	upd.AddConditionalFormat(sheet, 4, rule("f"))
+
+	var indexes []int64
+	for _, item := range upd.requestQueue {
+		switch req := item.request; {
+		case req.DeleteConditionalFormatRule != nil:
+			indexes = append(indexes, req.DeleteConditionalFormatRule.Index)
+		case req.UpdateConditionalFormatRule != nil:
+			indexes = append(indexes, req.UpdateConditionalFormatRule.Index)
+		case req.AddConditionalFormatRule != nil:
+			indexes = append(indexes, req.AddConditionalFormatRule.Index)
+		}
+		item.apply(nil)
+	}
+	assert.Equal(t, []int64{0, 1, 1, 0, 3, 4}, indexes)
+	assert.Equal(t, "BbDef", names())
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"math"
+	"time"
+)
+
+// Sheets stores dates and times as serial numbers: days since 1899-12-30,
+// with the fraction giving the time of day. A serial number is a wall
+// clock reading with no zone of its own; the spreadsheet's time zone gives
+// it one.
+
+// serialEpoch is day zero of serial dates.
+var serialEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+const secondsPerDay = 24 * 60 * 60
+
+// SerialToTime converts a serial date to a time in loc, rounded to the
+// millisecond.
+func SerialToTime(serial float64, loc *time.Location) time.Time {
+	days := math.Floor(serial)
+	ms := math.Round((serial - days) * secondsPerDay * 1000)
+	wall := serialEpoch.AddDate(0, 0, int(days)).Add(time.Duration(ms) * time.Millisecond)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(),
+		wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// TimeToSerial converts t to a serial date, reading its wall clock in loc.
+func TimeToSerial(t time.Time, loc *time.Location) float64 {
+	t = t.In(loc)
+	wall := time.Date(t.Year(), t.Month(), t.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	secs := wall.Unix() - serialEpoch.Unix()
+	return float64(secs)/secondsPerDay + float64(wall.Nanosecond())/(secondsPerDay*1e9)
+}
+
+// SerialToTime converts a serial date to a time in the spreadsheet's time
+// zone.
+func (db *SSDB) SerialToTime(serial float64) time.Time {
+	return SerialToTime(serial, db.Location())
+}
+
+// TimeToSerial converts t to a serial date in the spreadsheet's time zone.
+func (db *SSDB) TimeToSerial(t time.Time) float64 {
+	return TimeToSerial(t, db.Location())
+}
+
+// TimeEncoding selects how Timestamp writes a time.
+type TimeEncoding int
+
+const (
+	// RFC3339Time writes text such as "2026-10-01T09:30:00-04:00".
+	RFC3339Time TimeEncoding = iota
+	// SerialTime writes a date-time value, which sorts and filters as a
+	// date in the spreadsheet.
+	SerialTime
+)
+
+// Timestamp returns t as a value for the Updater, in the spreadsheet's
+// time zone. A time.Time written as is gets SerialTime.
+func (db *SSDB) Timestamp(t time.Time, enc TimeEncoding) any {
+	if enc == SerialTime {
+		return t
+	}
+	return t.In(db.Location()).Format(time.RFC3339)
+}
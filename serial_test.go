@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestSerial(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	noon := time.Date(2025, 7, 4, 16, 0, 0, 0, time.UTC) // 12:00 in New York
+	assert.Equal(t, 45842.5, TimeToSerial(noon, ny))
+	assert.True(t, noon.Equal(SerialToTime(45842.5, ny)))
+	assert.Equal(t, 2.0, TimeToSerial(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), time.UTC))
+	assert.Equal(t, time.Date(1899, 12, 29, 18, 0, 0, 0, time.UTC), SerialToTime(-0.25, time.UTC))
+
+	when := time.Date(2026, 10, 1, 9, 30, 15, 250e6, ny)
+	assert.Equal(t, when, SerialToTime(TimeToSerial(when, ny), ny))
+
+	db := &SSDB{spreadsheet: &sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{TimeZone: "America/New_York"},
+	}}
+	assert.Equal(t, "2025-07-04T12:00:00-04:00", db.Timestamp(noon, RFC3339Time))
+	assert.Equal(t, noon, db.Timestamp(noon, SerialTime))
+}
+
+func TestDateFormatRequests(t *testing.T) {
+	dbrange := testRange(t, "A1:C1")
+	dbrange.sheet.Sheet.Data[0].RowData = []*sheets.RowData{{
+		Values: []*sheets.CellData{{}, {}, {
+			UserEnteredFormat: &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "DATE", Pattern: "yyyy-mm-dd"}},
+		}},
+	}}
+	now := time.Now()
+	rowData := BuildRowdataAny([][]any{{now, "text", now}})
+	assert.NotNil(t, rowData[0].Values[0].UserEnteredValue.NumberValue)
+
+	reqs := numberFormatRequests(dbrange, rowData)
+	assert.Len(t, reqs, 1) // C1 keeps its own date format
+	assert.Equal(t, "userEnteredFormat.numberFormat", reqs[0].RepeatCell.Fields)
+	assert.Equal(t, int64(0), reqs[0].RepeatCell.Range.StartColumnIndex)
+	assert.Equal(t, "DATE_TIME", reqs[0].RepeatCell.Cell.UserEnteredFormat.NumberFormat.Type)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package ssdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateCellsSkip(t *testing.T) {
+	gridRange := testRange(t, "B2:F3").gridRange
+	data := [][]any{{"a", Skip, "c", "d", Skip}, {Skip, Skip}}
+	rowData := BuildRowdataAny(data)
+	assert.Nil(t, rowData[0].Values[1])
+
+	var got []string
+	for _, req := range updateCellsRequests(gridRange, data, rowData) {
+		uc := req.UpdateCells
+		text := FormatA1("Sheet1", uc.Range) + ":"
+		for _, cell := range uc.Rows[0].Values {
+			if cell == nil {
+				text += " nil"
+				continue
+			}
+			text += " " + *cell.UserEnteredValue.StringValue
+		}
+		got = append(got, text)
+	}
+	// the short second row still clears D3:F3, as without Skip
+	assert.Equal(t, []string{"Sheet1!B2: a", "Sheet1!D2:E2: c d", "Sheet1!D3:F3: nil nil nil"}, got)
+
+	reqs := updateCellsRequests(gridRange, [][]any{{"a", true}}, BuildRowdataAny([][]any{{"a", true}}))
+	assert.Len(t, reqs, 1)
+	assert.Equal(t, gridRange.EndColumnIndex, reqs[0].UpdateCells.Range.EndColumnIndex)
+	assert.True(t, *reqs[0].UpdateCells.Rows[0].Values[1].UserEnteredValue.BoolValue)
+}
@@ -7,19 +7,17 @@
 package ssaudit
 
 import (
-	"time"
-
 	"github.com/clucia/ssdb"
 	"github.com/clucia/ssdb/sslist"
 )
 
 func (sslog *SSAudit) AuditUpdate(updater *ssdb.Updater, dat ...any) {
+	sslist := (*sslist.SSList)(sslog)
 	line := []any{
-		time.Now().Format(time.RFC3339),
+		sslist.Timestamp(),
 	}
 	line = append(line, dat...)
 	vals := [][]any{line}
-	sslist := (*sslist.SSList)(sslog)
 	sslist.AppendBlank(updater, vals)
 }
 
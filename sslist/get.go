@@ -7,6 +7,8 @@
 package sslist
 
 import (
+	"time"
+
 	"github.com/clucia/ssdb"
 )
 
@@ -34,3 +36,9 @@ func (sslist *SSList) GetAppendLine() (newRowN int64) {
 	sslist.minAppendLine = newRowN + 1
 	return newRowN
 }
+
+// Timestamp returns the current time encoded as set by TimeEncoding, for
+// the first column of a log line.
+func (sslist *SSList) Timestamp() any {
+	return sslist.DB.Timestamp(time.Now(), sslist.TimeEncoding)
+}
@@ -17,6 +17,7 @@ type SSList struct {
 	sheetName     string
 	Sheet         *ssdb.Sheet
 	minAppendLine int64
+	TimeEncoding  ssdb.TimeEncoding // how Timestamp writes times
 }
 
 var ErrSheetNotFound = errors.New("sheet not found")
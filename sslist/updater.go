@@ -23,3 +23,11 @@ func (sslist *SSList) AppendBlank(updater *ssdb.Updater, _data [][]any) {
 	dbrange := sslist.GetAppendRange(inrows, incolumns)
 	updater.Update(dbrange, _data)
 }
+
+// AppendTagged appends _data like AppendBlank and tags each new row with
+// the matching entry of ids so it can later be found with UpdateByID.
+func (sslist *SSList) AppendTagged(updater *ssdb.Updater, ids []string, _data [][]any) {
+	inrows, incolumns := anySize(_data)
+	dbrange := sslist.GetAppendRange(inrows, incolumns)
+	updater.UpdateTagged(dbrange, _data, ids)
+}
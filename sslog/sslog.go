@@ -8,17 +8,16 @@ package sslog
 
 import (
 	"context"
-	"time"
 
 	"github.com/clucia/ssdb"
 	"github.com/clucia/ssdb/sslist"
 )
 
 func (sslog *SSLog) Log(updater *ssdb.Updater, dat ...any) {
-	line := []any{time.Now().Format(time.RFC3339)}
+	sslist := (*sslist.SSList)(sslog)
+	line := []any{sslist.Timestamp()}
 	line = append(line, dat...)
 	vals := [][]any{line}
-	sslist := (*sslist.SSList)(sslog)
 	sslist.AppendBlank(updater, vals)
 	updater.Sync()
 }
@@ -27,7 +26,7 @@ func (sslog *SSLog) LogErr(ctx context.Context, dat ...any) {
 	sslist := (*sslist.SSList)(sslog)
 	updater := sslist.DB.NewUpdater()
 	line := []any{}
-	line = append(line, time.Now().Format(time.RFC3339))
+	line = append(line, sslist.Timestamp())
 	line = append(line, "ERROR")
 	line = append(line, dat...)
 	vals := [][]any{line}
@@ -39,7 +38,7 @@ func (sslog *SSLog) LogWithData(ctx context.Context, dat ...any) {
 	sslist := (*sslist.SSList)(sslog)
 	updater := sslist.DB.NewUpdater()
 	line := []any{}
-	line = append(line, time.Now().Format(time.RFC3339))
+	line = append(line, sslist.Timestamp())
 	line = append(line, "DATA")
 	line = append(line, dat...)
 	vals := [][]any{line}
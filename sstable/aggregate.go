@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clucia/ssdb"
+)
+
+// Result is a computed table: a header and rows of values.
+type Result struct {
+	Header []string
+	Rows   [][]any
+}
+
+// Values returns the header and rows together, ready for Updater.Update.
+func (res *Result) Values() [][]any {
+	header := make([]any, len(res.Header))
+	for i, hdr := range res.Header {
+		header[i] = hdr
+	}
+	return append([][]any{header}, res.Rows...)
+}
+
+// Aggregate is a function over one column of each group, for GroupBy and
+// Select. Blank cells are skipped.
+type Aggregate struct {
+	fn     string // COUNT, SUM, AVG, MIN or MAX
+	column string // the header, "*" for COUNT(*)
+}
+
+// Count counts the rows.
+func Count() Aggregate { return Aggregate{fn: "COUNT", column: "*"} }
+
+// CountOf counts the non-blank cells of column.
+func CountOf(column string) Aggregate { return Aggregate{fn: "COUNT", column: column} }
+
+// Sum adds up column, which must hold numbers.
+func Sum(column string) Aggregate { return Aggregate{fn: "SUM", column: column} }
+
+// Avg averages column, which must hold numbers.
+func Avg(column string) Aggregate { return Aggregate{fn: "AVG", column: column} }
+
+// Min picks the least cell of column, ordered as Query.OrderBy orders.
+func Min(column string) Aggregate { return Aggregate{fn: "MIN", column: column} }
+
+// Max picks the greatest cell of column.
+func Max(column string) Aggregate { return Aggregate{fn: "MAX", column: column} }
+
+// String is the aggregate's header in results, e.g. SUM(Amount).
+func (agg Aggregate) String() string {
+	return agg.fn + "(" + agg.column + ")"
+}
+
+// compute applies the function to the cells of col in rows, or counts the
+// rows if col is negative. COUNT is an int64, SUM and AVG are float64s,
+// and MIN and MAX the value of the cell they pick: a time.Time for dates,
+// a float64 for other numbers, text otherwise. AVG, MIN and MAX of no
+// cells are nil.
+func (agg Aggregate) compute(rows []*ssdb.Row, col int64) (val any, err error) {
+	if col < 0 {
+		return int64(len(rows)), nil
+	}
+	var cells []*ssdb.Cell
+	for _, row := range rows {
+		if cell := row.GetCellN(col); !cell.IsBlank() {
+			cells = append(cells, cell)
+		}
+	}
+	switch agg.fn {
+	case "COUNT":
+		return int64(len(cells)), nil
+	case "SUM", "AVG":
+		sum := 0.0
+		for _, cell := range cells {
+			f, err := cell.GetFloat()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", agg, err)
+			}
+			sum += f
+		}
+		switch {
+		case agg.fn == "SUM":
+			return sum, nil
+		case len(cells) == 0:
+			return nil, nil
+		}
+		return sum / float64(len(cells)), nil
+	case "MIN", "MAX":
+		if len(cells) == 0 {
+			return nil, nil
+		}
+		best := cells[0]
+		for _, cell := range cells[1:] {
+			c := compareCells(cell, best)
+			if agg.fn == "MIN" && c < 0 || agg.fn == "MAX" && c > 0 {
+				best = cell
+			}
+		}
+		return cellResult(best), nil
+	}
+	return nil, fmt.Errorf("%s: unknown function", agg)
+}
+
+// cellResult is the typed value of a cell: a time.Time if it is formatted
+// as a date or is text that reads as one, a float64 if it is a number, and
+// its text otherwise.
+func cellResult(cell *ssdb.Cell) any {
+	f, numErr := cell.GetFloat()
+	isDate := numErr != nil
+	if format := cell.Cell.EffectiveFormat; format != nil && format.NumberFormat != nil {
+		isDate = strings.Contains(format.NumberFormat.Type, "DATE")
+	}
+	if isDate {
+		if t, err := cell.GetTime(); err == nil {
+			return t
+		}
+	}
+	if numErr == nil {
+		return f
+	}
+	return cell.GetString()
+}
+
+// Grouping is a table split into groups, see GroupBy.
+type Grouping struct {
+	table   *SSTable
+	columns []string
+}
+
+// GroupBy groups the non-blank rows below the header by the text in
+// columns, for Agg:
+//
+//	res, err := payments.GroupBy("Event").Agg(sstable.Count(), sstable.Sum("Amount"))
+//	updater.Update(summary.Resize(int64(len(res.Rows)+1), 3), res.Values())
+func (sstable *SSTable) GroupBy(columns ...string) *Grouping {
+	return &Grouping{table: sstable, columns: columns}
+}
+
+// Agg computes aggs for each group. The result has a column per grouping
+// column, holding its text, then one per aggregate, and a row per group in
+// order of first appearance. Without grouping columns there is a single
+// row, even for an empty table.
+func (g *Grouping) Agg(aggs ...Aggregate) (res *Result, err error) {
+	table := g.table
+	var groupCols, aggCols []int64
+	res = &Result{}
+	for _, name := range g.columns {
+		col, err := table.columnNamed(name)
+		if err != nil {
+			return nil, err
+		}
+		groupCols = append(groupCols, col)
+		res.Header = append(res.Header, name)
+	}
+	for _, agg := range aggs {
+		col := int64(-1)
+		if agg.column != "*" {
+			if col, err = table.columnNamed(agg.column); err != nil {
+				return nil, err
+			}
+		}
+		aggCols = append(aggCols, col)
+		res.Header = append(res.Header, agg.String())
+	}
+	var rows []*ssdb.Row
+	table.currentSheet().RowIter(func(row *ssdb.Row) {
+		if row.N > 0 && !row.IsBlank() {
+			rows = append(rows, row)
+		}
+	})
+	groups := groupRows(rows, groupCols)
+	if len(groupCols) == 0 && len(groups) == 0 {
+		groups = [][]*ssdb.Row{nil} // aggregates over no rows
+	}
+	for _, group := range groups {
+		line := make([]any, 0, len(res.Header))
+		for _, col := range groupCols {
+			line = append(line, group[0].GetCellN(col).GetString())
+		}
+		for i, agg := range aggs {
+			val, err := agg.compute(group, aggCols[i])
+			if err != nil {
+				return nil, err
+			}
+			line = append(line, val)
+		}
+		res.Rows = append(res.Rows, line)
+	}
+	return res, nil
+}
+
+// groupRows splits rows by the text in cols, keeping the groups in order
+// of first appearance.
+func groupRows(rows []*ssdb.Row, cols []int64) (groups [][]*ssdb.Row) {
+	index := map[string]int{}
+	for _, row := range rows {
+		keys := make([]string, len(cols))
+		for i, col := range cols {
+			keys[i] = row.GetCellN(col).GetString()
+		}
+		key := strings.Join(keys, "\x00")
+		n, ok := index[key]
+		if !ok {
+			n = len(groups)
+			index[key] = n
+			groups = append(groups, nil)
+		}
+		groups[n] = append(groups[n], row)
+	}
+	return groups
+}
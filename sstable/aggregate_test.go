@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clucia/ssdb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestGroupBy(t *testing.T) {
+	table := testTable([][]string{
+		{"Event", "Amount", "Date", "Member"},
+		{"Gala", "$40.00", "2026-03-01", "Dee"},
+		{"Picnic", "9.5", "2026-01-15", "Ann"},
+		{"Gala", "35", "2026-02-10", ""},
+		{},
+		{"Gala", "", "2025-12-30", "Bob"},
+	})
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	res, err := table.GroupBy("Event").Agg(Count(), CountOf("Member"), Sum("Amount"), Min("Date"), Max("Date"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Event", "COUNT(*)", "COUNT(Member)", "SUM(Amount)", "MIN(Date)", "MAX(Date)"}, res.Header)
+	assert.Equal(t, [][]any{
+		{"Gala", int64(3), int64(2), 75.0, day(2025, 12, 30), day(2026, 3, 1)},
+		{"Picnic", int64(1), int64(1), 9.5, day(2026, 1, 15), day(2026, 1, 15)},
+	}, res.Rows)
+	assert.Equal(t, []any{"Event", "COUNT(*)", "COUNT(Member)", "SUM(Amount)", "MIN(Date)", "MAX(Date)"}, res.Values()[0])
+
+	res, err = table.GroupBy().Agg(Avg("Amount"), Max("Member"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]any{{84.5 / 3, "Dee"}}, res.Rows)
+
+	// aggregates over no cells are nil, and are written as blanks
+	res, err = testTable([][]string{{"Event", "Amount"}}).GroupBy().Agg(Count(), Avg("Amount"), Min("Amount"), Max("Amount"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]any{{int64(0), nil, nil, nil}}, res.Rows)
+	rowData := ssdb.BuildRowdataAny(res.Values())
+	assert.Equal(t, 0.0, *rowData[1].Values[0].UserEnteredValue.NumberValue)
+	for _, cell := range rowData[1].Values[1:] {
+		assert.Nil(t, cell.UserEnteredValue)
+	}
+
+	_, err = table.GroupBy("Member").Agg(Sum("Event"))
+	assert.ErrorIs(t, err, ssdb.ErrCellType)
+	_, err = table.GroupBy("Nope").Agg(Count())
+	assert.ErrorIs(t, err, ErrColumnNotFound)
+
+	// a date the API sends as a formatted serial
+	serial := 46000.0
+	table.sheet.Sheet.Data[0].RowData[1].Values[2] = &sheets.CellData{
+		FormattedValue:  "2025-12-09",
+		EffectiveValue:  &sheets.ExtendedValue{NumberValue: &serial},
+		EffectiveFormat: &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "DATE"}},
+	}
+	table.Reindex()
+	res, err = table.GroupBy("Event").Agg(Min("Date"))
+	assert.NoError(t, err)
+	assert.Equal(t, day(2025, 12, 9), res.Rows[0][1])
+}
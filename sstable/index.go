@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/clucia/ssdb"
+)
+
+var ErrNoIndex = errors.New("column is not indexed")
+
+// tableIndex maps row keys (the text in the first column) to rows, and
+// headers to columns. Duplicates map to -1 so lookups can report them.
+// As GetRowByName always has, the keys include the header row's and blank
+// ones.
+type tableIndex struct {
+	generation uint64
+	sheet      *ssdb.Sheet // the sheet the index was built from
+	rows       map[string]int64
+	columns    map[string]int64
+	secondary  map[string]*secondaryIndex // by header, see AddIndex
+}
+
+// secondaryIndex maps the text in one column to the rows holding it.
+type secondaryIndex struct {
+	column int64
+	err    error // the header is missing or duplicated
+	rows   map[string][]int64
+}
+
+// index returns the table's index, building it if the cache has changed
+// since it was last built.
+func (sstable *SSTable) index() *tableIndex {
+	sstable.mu.Lock()
+	defer sstable.mu.Unlock()
+	generation := sstable.DB.Generation()
+	if sstable.idx != nil && sstable.idx.generation == generation {
+		return sstable.idx
+	}
+	sheet := sstable.lookupSheet()
+	idx := &tableIndex{
+		generation: generation,
+		sheet:      sheet,
+		rows:       map[string]int64{},
+		columns:    map[string]int64{},
+	}
+	add := func(m map[string]int64, key string, n int64) {
+		if _, dup := m[key]; dup {
+			n = -1
+		}
+		m[key] = n
+	}
+	sheet.RowIter(func(row *ssdb.Row) {
+		if row.N == 0 {
+			row.CellIter(func(cell *ssdb.Cell) {
+				if text := cell.GetString(); text != "" {
+					add(idx.columns, text, cell.N)
+				}
+			})
+		}
+		add(idx.rows, row.GetCellN(0).GetString(), row.N)
+	})
+	idx.secondary = map[string]*secondaryIndex{}
+	for name := range sstable.indexes {
+		sec := &secondaryIndex{rows: map[string][]int64{}}
+		idx.secondary[name] = sec
+		sec.column, sec.err = idx.column(name)
+		if errors.Is(sec.err, ErrLookupFailed) {
+			sec.err = ErrColumnNotFound
+		}
+		if sec.err != nil {
+			sec.err = fmt.Errorf("%s: %q: %w", sstable.sheetName, name, sec.err)
+			continue
+		}
+		sheet.RowIter(func(row *ssdb.Row) {
+			if text := row.GetCellN(sec.column).GetString(); row.N > 0 && text != "" {
+				sec.rows[text] = append(sec.rows[text], row.N)
+			}
+		})
+	}
+	sstable.idx = idx
+	return idx
+}
+
+// Reindex drops the index, so the next lookup rebuilds it. It is only
+// needed after changing the cached sheet by hand; Loader, Merge and Sync
+// are noticed on their own.
+func (sstable *SSTable) Reindex() {
+	sstable.mu.Lock()
+	sstable.idx = nil
+	sstable.mu.Unlock()
+}
+
+// rowByKey returns the row with key in the first column.
+func (sstable *SSTable) rowByKey(key string) (*ssdb.Row, error) {
+	idx := sstable.index()
+	n, ok := idx.rows[key]
+	switch {
+	case !ok:
+		return nil, ErrLookupFailed
+	case n < 0:
+		return nil, ErrDuplicateRowKey
+	}
+	return idx.sheet.GetRowN(n), nil
+}
+
+// columnByName returns the column with header name.
+func (sstable *SSTable) columnByName(name string) (int64, error) {
+	return sstable.index().column(name)
+}
+
+// columnNamed is columnByName with errors for users: a missing column is
+// ErrColumnNotFound, and the error names the table and column.
+func (sstable *SSTable) columnNamed(name string) (int64, error) {
+	col, err := sstable.columnByName(name)
+	if errors.Is(err, ErrLookupFailed) {
+		err = ErrColumnNotFound
+	}
+	if err != nil {
+		return -1, fmt.Errorf("%s: %q: %w", sstable.sheetName, name, err)
+	}
+	return col, nil
+}
+
+func (idx *tableIndex) column(name string) (int64, error) {
+	n, ok := idx.columns[name]
+	switch {
+	case !ok:
+		return -1, ErrLookupFailed
+	case n < 0:
+		return -1, ErrDuplicateColumnKey
+	}
+	return n, nil
+}
+
+// AddIndex declares a secondary index on the column headed column, for
+// FindBy and FindAllBy. Like the key index it is rebuilt when the cache
+// changes. A unique index refuses to be declared over duplicate values.
+func (sstable *SSTable) AddIndex(column string, unique bool) (err error) {
+	sstable.mu.Lock()
+	if sstable.indexes == nil {
+		sstable.indexes = map[string]bool{}
+	}
+	sstable.indexes[column] = unique
+	sstable.idx = nil
+	sstable.mu.Unlock()
+
+	sec := sstable.index().secondary[column]
+	err = sec.err
+	for value, rows := range sec.rows {
+		if err == nil && unique && len(rows) > 1 {
+			err = fmt.Errorf("%s: %s %q: %w", sstable.sheetName, column, value, ErrDuplicateRowKey)
+		}
+	}
+	if err != nil {
+		sstable.mu.Lock()
+		delete(sstable.indexes, column)
+		sstable.idx = nil
+		sstable.mu.Unlock()
+	}
+	return err
+}
+
+// secondary returns the index on column and the sheet it was built from.
+func (sstable *SSTable) secondary(column string) (*secondaryIndex, *ssdb.Sheet, error) {
+	idx := sstable.index()
+	sec := idx.secondary[column]
+	switch {
+	case sec == nil:
+		return nil, nil, fmt.Errorf("%s: %q: %w", sstable.sheetName, column, ErrNoIndex)
+	case sec.err != nil:
+		return nil, nil, sec.err
+	}
+	return sec, idx.sheet, nil
+}
+
+// FindBy returns the one row whose column holds value. It fails with
+// ErrLookupFailed if there is none and ErrDuplicateRowKey if there are
+// more, which for a unique index means the sheet was edited since.
+func (sstable *SSTable) FindBy(column, value string) (row *ssdb.Row, err error) {
+	sec, sheet, err := sstable.secondary(column)
+	if err != nil {
+		return nil, err
+	}
+	switch rows := sec.rows[value]; len(rows) {
+	case 0:
+		return nil, fmt.Errorf("%s: %s %q: %w", sstable.sheetName, column, value, ErrLookupFailed)
+	case 1:
+		return sheet.GetRowN(rows[0]), nil
+	}
+	return nil, fmt.Errorf("%s: %s %q: %w", sstable.sheetName, column, value, ErrDuplicateRowKey)
+}
+
+// FindAllBy returns every row whose column holds value, in sheet order.
+func (sstable *SSTable) FindAllBy(column, value string) (rows []*ssdb.Row, err error) {
+	sec, sheet, err := sstable.secondary(column)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range sec.rows[value] {
+		rows = append(rows, sheet.GetRowN(n))
+	}
+	return rows, nil
+}
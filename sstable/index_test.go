@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+
+	"github.com/clucia/ssdb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestIndex(t *testing.T) {
+	table := testTable([][]string{
+		{"Key", "Global", "Local", "Local"},
+		{"ClubName", "Rotary", "North"},
+		{"Dup", "1"},
+		{},
+		{"Dup", "2"},
+		{"", "3"},
+	})
+	row, err := table.GetRowByName("ClubName")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), row.N)
+	_, err = table.GetRowByName("Dup")
+	assert.ErrorIs(t, err, ErrDuplicateRowKey)
+	row, err = table.GetRowByName("Key") // the header counts, as it always has
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), row.N)
+	_, err = table.GetRowByName("") // rows 3 and 5
+	assert.ErrorIs(t, err, ErrDuplicateRowKey)
+
+	cell, err := table.QuietLookup("ClubName", "Global")
+	assert.NoError(t, err)
+	assert.Equal(t, "Rotary", cell.GetString())
+	_, err = table.QuietLookup("ClubName", "Local")
+	assert.ErrorIs(t, err, ssdb.ErrDuplicateColumnKey)
+	_, err = table.QuietLookup("ClubName", "Nope")
+	assert.ErrorIs(t, err, ErrLookupFailed)
+
+	assert.Equal(t, int64(1), table.HSearch("Global").N)
+	assert.Nil(t, table.HSearch("Local"))
+	assert.Equal(t, int64(1), table.VSearch("Key", "ClubName").N)
+	assert.Nil(t, table.VSearch("Key", "Key")) // VSearch skips the header
+	assert.Equal(t, int64(2), table.VSearch("Global", "1").N)
+	assert.Nil(t, table.VSearch("Nope", "1"))
+
+	// the cache changes by hand, so the index has to be told
+	rowData := table.sheet.Sheet.Data[0].RowData
+	rowData[4].Values[0] = &sheets.CellData{FormattedValue: "Other"}
+	_, err = table.GetRowByName("Other")
+	assert.ErrorIs(t, err, ErrLookupFailed)
+	table.Reindex()
+	row, err = table.GetRowByName("Dup")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), row.N)
+}
+
+func TestSecondaryIndex(t *testing.T) {
+	table := testTable([][]string{
+		{"ID", "Email", "Phone"},
+		{"a1", "ann@example.org", "555-0100"},
+		{"b2", "bob@example.org", "555-0100"},
+		{"c3", "", "555-0199"},
+	})
+	_, err := table.FindBy("Email", "ann@example.org")
+	assert.ErrorIs(t, err, ErrNoIndex)
+	assert.NoError(t, table.AddIndex("Email", true))
+	assert.ErrorIs(t, table.AddIndex("Phone", true), ErrDuplicateRowKey)
+	assert.ErrorIs(t, table.AddIndex("Fax", false), ErrColumnNotFound)
+	assert.NoError(t, table.AddIndex("Phone", false))
+
+	row, err := table.FindBy("Email", "bob@example.org")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), row.N)
+	_, err = table.FindBy("Email", "")
+	assert.ErrorIs(t, err, ErrLookupFailed)
+	_, err = table.FindBy("Phone", "555-0100")
+	assert.ErrorIs(t, err, ErrDuplicateRowKey)
+	rows, err := table.FindAllBy("Phone", "555-0100")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	_, err = table.FindAllBy("Fax", "1")
+	assert.ErrorIs(t, err, ErrNoIndex)
+
+	table.sheet.Sheet.Data[0].RowData[3].Values[1] = &sheets.CellData{FormattedValue: "cy@example.org"}
+	table.Reindex()
+	row, err = table.FindBy("Email", "cy@example.org")
+	assert.NoError(t, err)
+	assert.Equal(t, "c3", row.GetCellN(0).GetString())
+}
@@ -18,7 +18,7 @@ func (sstable *SSTable) Iter(f func(_map map[string]any)) {
 	var enableHdrCell *ssdb.Cell
 	var _map map[string]any
 
-	sstable.sheet.RowIter(func(row *ssdb.Row) {
+	sstable.currentSheet().RowIter(func(row *ssdb.Row) {
 		_map = nil
 		switch {
 		case row.N == 0:
@@ -56,7 +56,7 @@ func (sstable *SSTable) Get2DMap(keyColumn string) (_map map[string]map[string]a
 	var keyFlag *ssdb.Cell
 
 	_map = nil
-	sstable.sheet.RowIter(func(row *ssdb.Row) {
+	sstable.currentSheet().RowIter(func(row *ssdb.Row) {
 		switch {
 		case row.N == 0:
 			hdrrow = row
@@ -97,7 +97,7 @@ func (sstable *SSTable) Get2DMap(keyColumn string) (_map map[string]map[string]a
 func (sstable *SSTable) GetHeaders() (headers []string) {
 	var hdrrow *ssdb.Row
 
-	hdrrow = sstable.sheet.GetRowN(0)
+	hdrrow = sstable.currentSheet().GetRowN(0)
 	hdrrow.CellIter(func(cell *ssdb.Cell) {
 		headers = append(headers, cell.GetString())
 	})
@@ -105,7 +105,7 @@ func (sstable *SSTable) GetHeaders() (headers []string) {
 }
 
 func (sstable *SSTable) ListColumn(N int64) (list []string) {
-	sstable.sheet.RowIter(func(row *ssdb.Row) {
+	sstable.currentSheet().RowIter(func(row *ssdb.Row) {
 		switch {
 		case row.N == 0:
 			return
@@ -120,7 +120,7 @@ func (sstable *SSTable) ListColumn(N int64) (list []string) {
 
 func (sstable *SSTable) ListColumnByName(name string) (list []string) {
 	var hdrrow *ssdb.Row
-	hdrrow = sstable.sheet.GetRowN(0)
+	hdrrow = sstable.currentSheet().GetRowN(0)
 	colN := int64(-1)
 	var err bool
 	hdrrow.CellIter(func(cell *ssdb.Cell) {
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"github.com/clucia/ssdb"
+)
+
+// JoinResult is two tables joined row by row. Header names each column
+// as Sheet.Header. Orphans are the rows of the left table whose key is not
+// blank but matches no row of the right table.
+type JoinResult struct {
+	Result
+	Orphans []*ssdb.Row
+}
+
+// Join pairs every row of the table with every row of right whose
+// rightKey column holds the text in its leftKey column, e.g. Payments on
+// MemberID with Members on ID. Rows with no match are left out.
+func (sstable *SSTable) Join(right *SSTable, leftKey, rightKey string) (res *JoinResult, err error) {
+	return sstable.join(right, leftKey, rightKey, false)
+}
+
+// LeftJoin is Join, except rows of the table with no match are kept, with
+// the right table's columns blank.
+func (sstable *SSTable) LeftJoin(right *SSTable, leftKey, rightKey string) (res *JoinResult, err error) {
+	return sstable.join(right, leftKey, rightKey, true)
+}
+
+func (sstable *SSTable) join(right *SSTable, leftKey, rightKey string, keep bool) (res *JoinResult, err error) {
+	leftCol, err := sstable.columnNamed(leftKey)
+	if err != nil {
+		return nil, err
+	}
+	rightCol, err := right.columnNamed(rightKey)
+	if err != nil {
+		return nil, err
+	}
+	res = &JoinResult{}
+	leftCols := sstable.joinColumns(&res.Header)
+	rightCols := right.joinColumns(&res.Header)
+
+	matches := map[string][]*ssdb.Row{}
+	right.currentSheet().RowIter(func(row *ssdb.Row) {
+		if key := row.GetCellN(rightCol).GetString(); row.N > 0 && key != "" {
+			matches[key] = append(matches[key], row)
+		}
+	})
+	sstable.currentSheet().RowIter(func(row *ssdb.Row) {
+		if row.N == 0 || row.IsBlank() {
+			return
+		}
+		key := row.GetCellN(leftCol).GetString()
+		found := matches[key]
+		if key != "" && len(found) == 0 {
+			res.Orphans = append(res.Orphans, row)
+		}
+		if len(found) == 0 && keep {
+			found = []*ssdb.Row{nil}
+		}
+		for _, match := range found {
+			line := make([]any, 0, len(res.Header))
+			for _, col := range leftCols {
+				line = append(line, row.GetCellN(col).GetString())
+			}
+			for _, col := range rightCols {
+				line = append(line, match.GetCellN(col).GetString())
+			}
+			res.Rows = append(res.Rows, line)
+		}
+	})
+	return res, nil
+}
+
+// joinColumns adds the table's headers, prefixed with its name, to header
+// and returns their columns.
+func (sstable *SSTable) joinColumns(header *[]string) (cols []int64) {
+	for i, hdr := range sstable.GetHeaders() {
+		if hdr != "" {
+			*header = append(*header, sstable.sheetName+"."+hdr)
+			cols = append(cols, int64(i))
+		}
+	}
+	return cols
+}
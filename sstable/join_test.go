@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin(t *testing.T) {
+	members := testTable([][]string{
+		{"ID", "Name"},
+		{"m1", "Ann"},
+		{"m2", "Bob"},
+	})
+	payments := testTable([][]string{
+		{"MemberID", "Amount"},
+		{"m2", "10"},
+		{"m9", "20"},
+		{"m1", "30"},
+		{"", "40"},
+		{"m2", "50"},
+	})
+	payments.sheetName = "Payments"
+
+	res, err := payments.Join(members, "MemberID", "ID")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Payments.MemberID", "Payments.Amount", "Members.ID", "Members.Name"}, res.Header)
+	assert.Equal(t, [][]any{
+		{"m2", "10", "m2", "Bob"},
+		{"m1", "30", "m1", "Ann"},
+		{"m2", "50", "m2", "Bob"},
+	}, res.Rows)
+	assert.Len(t, res.Orphans, 1)
+	assert.Equal(t, int64(2), res.Orphans[0].N)
+	assert.Len(t, res.Values(), 4)
+
+	res, err = payments.LeftJoin(members, "MemberID", "ID")
+	assert.NoError(t, err)
+	assert.Len(t, res.Rows, 5)
+	assert.Equal(t, []any{"m9", "20", "", ""}, res.Rows[1])
+	assert.Equal(t, []any{"", "40", "", ""}, res.Rows[3])
+	assert.Len(t, res.Orphans, 1) // a blank key is not an orphan
+
+	res, err = members.Join(payments, "ID", "MemberID")
+	assert.NoError(t, err)
+	assert.Len(t, res.Rows, 3) // Bob paid twice
+	assert.Empty(t, res.Orphans)
+
+	_, err = payments.Join(members, "Member", "ID")
+	assert.ErrorIs(t, err, ErrColumnNotFound)
+}
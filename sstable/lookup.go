@@ -18,33 +18,25 @@ var ErrDuplicateRowKey = errors.New("duplicate row key")
 var ErrDuplicateColumnKey = errors.New("duplicate column key")
 
 func (sstbl *SSTable) HSearch(colMatch string) (foundCell *ssdb.Cell) {
-	var hdrrow *ssdb.Row
-	sstbl.sheet.RowIter(func(row *ssdb.Row) {
-		if row.N == 0 {
-			hdrrow = row
-		}
-	})
-	var err bool
-	hdrrow.CellIter(func(cell *ssdb.Cell) {
-		switch {
-		case err:
-			return
-		case foundCell != nil && cell.GetString() == colMatch:
-			err = true
-		case cell.GetString() == colMatch:
-			foundCell = cell
-		}
-	})
-	if err {
+	col, err := sstbl.columnByName(colMatch)
+	if err != nil {
 		return nil
 	}
-	return foundCell
+	return sstbl.currentSheet().GetRowN(0).GetCellN(col)
 }
 
 func (sstbl *SSTable) VSearch(colMatch string, colValue string) (foundrow *ssdb.Row) {
-	var err bool
 	cell := sstbl.HSearch(colMatch)
-	sstbl.sheet.RowIter(func(row *ssdb.Row) {
+	switch {
+	case cell == nil:
+		return nil
+	case cell.N == 0 && colValue != cell.GetString():
+		// the key index, which unlike VSearch also holds the header
+		foundrow, _ = sstbl.rowByKey(colValue)
+		return foundrow
+	}
+	var err bool
+	sstbl.currentSheet().RowIter(func(row *ssdb.Row) {
 		if row.N == 0 {
 			return
 		}
@@ -62,51 +54,26 @@ func (sstbl *SSTable) VSearch(colMatch string, colValue string) (foundrow *ssdb.
 	return //
 }
 
+// GetRowByName returns the row whose first cell holds rowMatch. The header
+// row is matched too, and a blank rowMatch finds the row with a blank key;
+// more than one match is ErrDuplicateRowKey.
 func (sstbl *SSTable) GetRowByName(rowMatch string) (row *ssdb.Row, err error) {
-	var foundRow *ssdb.Row
-	sstbl.sheet.RowIter(func(row *ssdb.Row) {
-		if err != nil {
-			return
-		}
-		if row.GetCellN(0).GetString() == rowMatch {
-			if foundRow != nil {
-				err = ErrDuplicateRowKey
-			}
-			foundRow = row
-		}
-	})
-	switch {
-	case err != nil:
-		return nil, err
-	case foundRow == nil:
-		return nil, ErrLookupFailed
-	default:
-		return foundRow, nil
-	}
+	return sstbl.rowByKey(rowMatch)
 }
 
 func (sstbl *SSTable) QuietLookup(rowMatch, colMatch string) (cell *ssdb.Cell, err error) {
-	var foundRow *ssdb.Row
-	foundRow, err = sstbl.GetRowByName(rowMatch)
-	switch {
-	case err != nil:
+	foundRow, err := sstbl.rowByKey(rowMatch)
+	if err != nil {
 		return nil, err
-	case foundRow == nil:
-		return nil, ErrLookupFailed
-	default:
-		// process below
 	}
-	var foundCell *ssdb.Cell
-	foundCell, err = foundRow.GetCellByName(colMatch)
+	col, err := sstbl.columnByName(colMatch)
 	switch {
+	case errors.Is(err, ErrDuplicateColumnKey):
+		return nil, ssdb.ErrDuplicateColumnKey
 	case err != nil:
-		return nil, err
-	case foundRow == nil:
 		return nil, ErrLookupFailed
-	default:
-		// process below
 	}
-	return foundCell, nil
+	return foundRow.GetCellN(col), nil
 }
 
 func (sstbl *SSTable) Lookup(rowMatch, colMatch string) (cell *ssdb.Cell, err error) {
@@ -140,7 +107,7 @@ func (sstbl *SSTable) NLookup(rowMatch string) (cell *ssdb.Cell, err error) {
 }
 
 func (sstbl *SSTable) GetKeys() (rowKeys, colKeys []string) {
-	sstbl.sheet.RowIter(func(row *ssdb.Row) {
+	sstbl.currentSheet().RowIter(func(row *ssdb.Row) {
 		if row.N == 0 {
 			return
 		}
@@ -157,7 +124,7 @@ func (sstbl *SSTable) GetKeys() (rowKeys, colKeys []string) {
 		}
 		rowKeys = append(rowKeys, row.GetCellN(0).GetString())
 	})
-	hdrrow := sstbl.sheet.GetRowN(0)
+	hdrrow := sstbl.currentSheet().GetRowN(0)
 	hdrrow.CellIter(func(cell *ssdb.Cell) {
 		colKeys = append(colKeys, cell.GetString())
 	})
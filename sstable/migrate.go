@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/clucia/ssdb"
+)
+
+var ErrMigrationConflict = errors.New("migration conflict")
+
+// Migration declares the header a table should have.
+type Migration struct {
+	Columns []string          // the declared columns, in order
+	Aliases map[string]string // old header to the column it becomes
+	Reorder bool              // move the declared columns into declared order
+}
+
+// Migration steps.
+const (
+	MigrateRename = "rename"
+	MigrateAdd    = "add"
+	MigrateMove   = "move"
+)
+
+// MigrationStep is one change to the header. Index is the column renamed
+// or added, or where a moved column ends up; From and FromIndex are the old
+// name and column. Indexes count the columns as they are when the step runs.
+type MigrationStep struct {
+	Op        string
+	Column    string
+	From      string
+	Index     int64
+	FromIndex int64
+}
+
+func (step MigrationStep) String() string {
+	switch step.Op {
+	case MigrateRename:
+		return fmt.Sprintf("rename %s %q to %q", ssdb.FormatColumn(step.Index), step.From, step.Column)
+	case MigrateAdd:
+		return fmt.Sprintf("add %q at %s", step.Column, ssdb.FormatColumn(step.Index))
+	case MigrateMove:
+		return fmt.Sprintf("move %q from %s to %s", step.Column, ssdb.FormatColumn(step.FromIndex), ssdb.FormatColumn(step.Index))
+	}
+	return step.Op
+}
+
+// MigrationPlan is the list of steps that takes a table to a Migration.
+type MigrationPlan struct {
+	table *SSTable
+	Steps []MigrationStep
+}
+
+func (plan *MigrationPlan) String() string {
+	lines := make([]string, len(plan.Steps))
+	for i, step := range plan.Steps {
+		lines[i] = step.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PlanMigration compares the header with m. Renames come first, then the
+// missing columns are added after the last header, then, if m.Reorder, the
+// declared columns are moved into order. Columns m doesn't declare keep
+// their places, and nothing is ever deleted.
+func (sstable *SSTable) PlanMigration(m *Migration) (plan *MigrationPlan, err error) {
+	headers := sstable.GetHeaders()
+	for len(headers) > 0 && headers[len(headers)-1] == "" {
+		headers = headers[:len(headers)-1]
+	}
+	index := func(name string) int64 {
+		return int64(slices.Index(headers, name))
+	}
+	for i, name := range m.Columns {
+		if name == "" || slices.Contains(m.Columns[:i], name) {
+			return nil, fmt.Errorf("%s: declared column %q: %w", sstable.sheetName, name, ErrMigrationConflict)
+		}
+	}
+	for i, hdr := range headers {
+		if hdr != "" && slices.Contains(headers[:i], hdr) {
+			return nil, fmt.Errorf("%s: %q: %w", sstable.sheetName, hdr, ErrDuplicateColumnKey)
+		}
+	}
+	plan = &MigrationPlan{table: sstable}
+	for _, from := range slices.Sorted(maps.Keys(m.Aliases)) {
+		to := m.Aliases[from]
+		col := index(from)
+		switch {
+		case col < 0:
+			continue // already renamed, or never there
+		case index(to) >= 0:
+			return nil, fmt.Errorf("%s: both %q and %q: %w", sstable.sheetName, from, to, ErrMigrationConflict)
+		}
+		headers[col] = to
+		plan.Steps = append(plan.Steps, MigrationStep{Op: MigrateRename, Column: to, From: from, Index: col, FromIndex: col})
+	}
+	for _, name := range m.Columns {
+		if index(name) >= 0 {
+			continue
+		}
+		headers = append(headers, name)
+		col := int64(len(headers) - 1)
+		plan.Steps = append(plan.Steps, MigrationStep{Op: MigrateAdd, Column: name, Index: col, FromIndex: col})
+	}
+	if !m.Reorder {
+		return plan, nil
+	}
+	// the declared columns fill the slots they already take, in order
+	want := slices.Clone(headers)
+	next := 0
+	for i, hdr := range want {
+		if slices.Contains(m.Columns, hdr) {
+			want[i] = m.Columns[next]
+			next++
+		}
+	}
+	for i := range want {
+		from := index(want[i])
+		if from == int64(i) {
+			continue
+		}
+		// columns before i are settled, so from is past i
+		headers = slices.Insert(slices.Delete(headers, int(from), int(from+1)), i, want[i])
+		plan.Steps = append(plan.Steps, MigrationStep{Op: MigrateMove, Column: want[i], Index: int64(i), FromIndex: from})
+	}
+	return plan, nil
+}
+
+// Apply queues the plan on updater. The header cells are written before
+// any columns move, so the whole plan can go in one Sync; the sheet is
+// reloaded after it if anything moved.
+func (plan *MigrationPlan) Apply(updater *ssdb.Updater) {
+	sheet := plan.table.currentSheet()
+	for _, step := range plan.Steps {
+		switch step.Op {
+		case MigrateRename, MigrateAdd:
+			updater.Update(sheet.NewDBRange(0, step.Index, 1, 1), [][]any{{step.Column}})
+		case MigrateMove:
+			updater.MoveColumns(sheet, step.FromIndex, 1, step.Index)
+		}
+	}
+}
+
+// Migrate plans m and applies it to updater in one go.
+func (sstable *SSTable) Migrate(updater *ssdb.Updater, m *Migration) (plan *MigrationPlan, err error) {
+	plan, err = sstable.PlanMigration(m)
+	if err != nil {
+		return nil, err
+	}
+	plan.Apply(updater)
+	return plan, nil
+}
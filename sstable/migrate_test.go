@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+
+	"github.com/clucia/ssdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanMigration(t *testing.T) {
+	table := testTable([][]string{
+		{"Balance", "Notes", "Name", "Ident", ""},
+		{"10", "vip", "Ann", "a1"},
+	})
+	m := &Migration{
+		Columns: []string{"ID", "Name", "Email", "Balance"},
+		Aliases: map[string]string{"Ident": "ID", "Old": "Gone"},
+	}
+	plan, err := table.PlanMigration(m)
+	assert.NoError(t, err)
+	assert.Equal(t, "rename D \"Ident\" to \"ID\"\nadd \"Email\" at E", plan.String())
+
+	m.Reorder = true
+	plan, err = table.PlanMigration(m)
+	assert.NoError(t, err)
+	// Balance Notes Name ID Email -> ID Notes Name Email Balance
+	assert.Equal(t, []MigrationStep{
+		{Op: MigrateRename, Column: "ID", From: "Ident", Index: 3, FromIndex: 3},
+		{Op: MigrateAdd, Column: "Email", Index: 4, FromIndex: 4},
+		{Op: MigrateMove, Column: "ID", Index: 0, FromIndex: 3},
+		{Op: MigrateMove, Column: "Notes", Index: 1, FromIndex: 2},
+		{Op: MigrateMove, Column: "Name", Index: 2, FromIndex: 3},
+		{Op: MigrateMove, Column: "Email", Index: 3, FromIndex: 4},
+	}, plan.Steps)
+
+	updater := (&ssdb.SSDB{}).NewUpdater()
+	plan.Apply(updater)
+	assert.Equal(t, int64(6), updater.Len())
+
+	m.Aliases["Notes"] = "Name"
+	_, err = table.PlanMigration(m)
+	assert.ErrorIs(t, err, ErrMigrationConflict)
+
+	plan, err = testTable([][]string{{"ID", "Name", "Email", "Balance"}}).PlanMigration(m)
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Steps)
+}
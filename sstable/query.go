@@ -0,0 +1,293 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/clucia/ssdb"
+)
+
+// Op is a Where comparison. In takes a slice and matches cells equal to
+// any element; Contains and HasPrefix work on the text; Blank and NotBlank
+// ignore the value.
+type Op int
+
+const (
+	Eq Op = iota
+	Ne
+	Lt
+	Le
+	Gt
+	Ge
+	In
+	Contains
+	HasPrefix
+	Blank
+	NotBlank
+)
+
+// Query selects rows of a table:
+//
+//	rows, err := table.Query().
+//		Where("Status", sstable.Eq, "Active").
+//		Where("Age", sstable.Gt, 18).
+//		OrderBy("Name").
+//		Limit(20).
+//		Rows()
+//
+// Cells are compared as the type of the value they are compared with:
+// numbers, time.Time, time.Duration and bools use the typed Cell getters
+// (a cell that doesn't convert matches nothing but Ne), anything else
+// compares as text. Conditions all have to hold.
+type Query struct {
+	table *SSTable
+	where []condition
+	order []ordering
+	limit int
+	err   error
+}
+
+type condition struct {
+	column int64
+	op     Op
+	val    any
+}
+
+type ordering struct {
+	column int64
+	desc   bool
+}
+
+// Query starts a query over the non-blank rows below the header.
+func (sstable *SSTable) Query() *Query {
+	return &Query{table: sstable}
+}
+
+func (q *Query) column(name string) int64 {
+	col, err := q.table.columnNamed(name)
+	if err != nil && q.err == nil {
+		q.err = err
+	}
+	return col
+}
+
+// Where adds the condition that the cell in column compares to val by op.
+func (q *Query) Where(column string, op Op, val any) *Query {
+	q.where = append(q.where, condition{column: q.column(column), op: op, val: val})
+	return q
+}
+
+// OrderBy sorts by column, ascending; later calls break ties. Numbers and
+// dates sort by value, other text by text, and blanks come last.
+func (q *Query) OrderBy(column string) *Query {
+	q.order = append(q.order, ordering{column: q.column(column)})
+	return q
+}
+
+// OrderByDesc is OrderBy, descending. Blanks still come last.
+func (q *Query) OrderByDesc(column string) *Query {
+	q.order = append(q.order, ordering{column: q.column(column), desc: true})
+	return q
+}
+
+// Limit keeps the first n rows; 0 keeps them all.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Rows returns the selected rows. It fails if the query names a column
+// the table doesn't have, or has twice.
+func (q *Query) Rows() (rows []*ssdb.Row, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	q.table.currentSheet().RowIter(func(row *ssdb.Row) {
+		if row.N == 0 || row.IsBlank() {
+			return
+		}
+		for _, cond := range q.where {
+			if !match(row.GetCellN(cond.column), cond.op, cond.val) {
+				return
+			}
+		}
+		rows = append(rows, row)
+	})
+	sortRows(rows, q.order)
+	if q.limit > 0 && len(rows) > q.limit {
+		rows = rows[:q.limit]
+	}
+	return rows, nil
+}
+
+// Maps returns the selected rows as maps from header to text.
+func (q *Query) Maps() (res []map[string]any, err error) {
+	rows, err := q.Rows()
+	if err != nil {
+		return nil, err
+	}
+	headers := q.table.GetHeaders()
+	for _, row := range rows {
+		m := map[string]any{}
+		for i, hdr := range headers {
+			if hdr != "" {
+				m[hdr] = row.GetCellN(int64(i)).GetString()
+			}
+		}
+		res = append(res, m)
+	}
+	return res, nil
+}
+
+// Scan fills dst with the selected rows, as SSTable.Scan does.
+func (q *Query) Scan(dst any) (err error) {
+	rows, err := q.Rows()
+	if err != nil {
+		return err
+	}
+	return q.table.scanRows(rows, dst)
+}
+
+// sortRows sorts rows by order, as Query.OrderBy describes.
+func sortRows(rows []*ssdb.Row, order []ordering) {
+	slices.SortStableFunc(rows, func(a, b *ssdb.Row) int {
+		for _, o := range order {
+			x, y := a.GetCellN(o.column), b.GetCellN(o.column)
+			c := compareCells(x, y)
+			if o.desc && !x.IsBlank() && !y.IsBlank() {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// match reports whether cell compares to val by op, as for Query.Where.
+func match(cell *ssdb.Cell, op Op, val any) bool {
+	switch op {
+	case Blank:
+		return cell.IsBlank()
+	case NotBlank:
+		return !cell.IsBlank()
+	case Contains:
+		return strings.Contains(cell.GetString(), fmt.Sprint(val))
+	case HasPrefix:
+		return strings.HasPrefix(cell.GetString(), fmt.Sprint(val))
+	case In:
+		list := reflect.ValueOf(val)
+		if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+			return match(cell, Eq, val)
+		}
+		for i := 0; i < list.Len(); i++ {
+			if match(cell, Eq, list.Index(i).Interface()) {
+				return true
+			}
+		}
+		return false
+	}
+	c, ok := compareValue(cell, val)
+	if !ok {
+		return op == Ne
+	}
+	switch op {
+	case Eq:
+		return c == 0
+	case Ne:
+		return c != 0
+	case Lt:
+		return c < 0
+	case Le:
+		return c <= 0
+	case Gt:
+		return c > 0
+	case Ge:
+		return c >= 0
+	}
+	return false
+}
+
+// compareValue compares cell with val, reading the cell as val's type.
+// ok is false if it doesn't read as one.
+func compareValue(cell *ssdb.Cell, val any) (c int, ok bool) {
+	switch v := val.(type) {
+	case string:
+		return strings.Compare(cell.GetString(), v), true
+	case time.Time:
+		t, err := cell.GetTime()
+		return t.Compare(v), err == nil
+	case time.Duration:
+		d, err := cell.GetDuration()
+		return cmp.Compare(d, v), err == nil
+	case bool:
+		b, err := cell.GetBool()
+		return cmp.Compare(boolInt(b), boolInt(v)), err == nil
+	}
+	var f float64
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = rv.Float()
+	default:
+		return strings.Compare(cell.GetString(), fmt.Sprint(val)), true
+	}
+	n, err := cell.GetFloat()
+	return cmp.Compare(n, f), err == nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// compareCells orders two cells the way the Sheets sort does: numbers
+// (dates and times included) by value, then text that reads as a date, then
+// other text, then blanks.
+func compareCells(a, b *ssdb.Cell) int {
+	rankA, rankB := sortRank(a), sortRank(b)
+	if rankA != rankB {
+		return cmp.Compare(rankA, rankB)
+	}
+	switch rankA {
+	case 0:
+		x, _ := a.GetFloat()
+		y, _ := b.GetFloat()
+		return cmp.Compare(x, y)
+	case 1:
+		x, _ := a.GetTime()
+		y, _ := b.GetTime()
+		return x.Compare(y)
+	}
+	return strings.Compare(a.GetString(), b.GetString())
+}
+
+func sortRank(cell *ssdb.Cell) int {
+	if cell.IsBlank() {
+		return 3
+	}
+	if _, err := cell.GetFloat(); err == nil {
+		return 0
+	}
+	if _, err := cell.GetTime(); err == nil {
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clucia/ssdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	table := testTable([][]string{
+		{"Name", "Status", "Age", "Joined", "Member"},
+		{"Dee", "Active", "40", "2021-05-01", "yes"},
+		{"Ann", "Active", "9", "2024-01-15", "no"},
+		{"Cy", "Lapsed", "33", "", "yes"},
+		{},
+		{"Bob", "Active", "n/a", "2019-11-30", "yes"},
+		{"Eve", "Active", "100", "2023-02-02", "yes"},
+	})
+	names := func(rows []*ssdb.Row, err error) (res []string) {
+		assert.NoError(t, err)
+		for _, row := range rows {
+			res = append(res, row.GetCellN(0).GetString())
+		}
+		return //
+	}
+	// 9 < 18 as numbers, though "9" > "18" as text; "n/a" is no number
+	assert.Equal(t, []string{"Dee", "Eve"}, names(table.Query().
+		Where("Status", Eq, "Active").Where("Age", Gt, 18).OrderBy("Name").Rows()))
+	assert.Equal(t, []string{"Bob", "Eve", "Dee", "Cy", "Ann"}, names(table.Query().OrderByDesc("Age").Rows())) // text after numbers
+	assert.Equal(t, []string{"Bob", "Dee"}, names(table.Query().
+		Where("Joined", Lt, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)).OrderBy("Joined").Rows()))
+	assert.Equal(t, []string{"Cy"}, names(table.Query().Where("Joined", Blank, nil).Rows()))
+	assert.Equal(t, []string{"Ann", "Cy"}, names(table.Query().Where("Name", In, []string{"Cy", "Ann"}).Rows()))
+	assert.Equal(t, []string{"Ann"}, names(table.Query().Where("Member", Eq, false).Rows()))
+	assert.Equal(t, []string{"Dee", "Eve"}, names(table.Query().Where("Name", Contains, "e").Limit(2).Rows()))
+	assert.Equal(t, []string{"Bob"}, names(table.Query().Where("Name", HasPrefix, "B").Rows()))
+
+	_, err := table.Query().Where("Nope", Eq, 1).Rows()
+	assert.ErrorIs(t, err, ErrColumnNotFound)
+
+	maps, err := table.Query().Where("Age", Ge, 100).Maps()
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"Name": "Eve", "Status": "Active", "Age": "100", "Joined": "2023-02-02", "Member": "yes"}}, maps)
+
+	var people []struct {
+		Name string
+		Age  int
+	}
+	assert.NoError(t, table.Query().Where("Status", Ne, "Active").Scan(&people))
+	assert.Len(t, people, 1)
+	assert.Equal(t, 33, people[0].Age)
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/clucia/ssdb"
+)
+
+// Struct fields map to columns by header. The column is named by the
+// field's ssdb tag, or by the field name if there is no tag:
+//
+//	type Member struct {
+//		Name   string    `ssdb:"Full Name"`
+//		Dues   float64   `ssdb:"Dues Paid,omitempty"`
+//		Joined time.Time `ssdb:"Joined"`
+//		Notes  string    `ssdb:"-"` // not stored
+//	}
+//
+// A column that a field names must exist unless the field is omitempty,
+// and must not appear twice; other headers may repeat.
+// Cells are converted with the typed Cell getters, so errors name the
+// offending cell. Supported field types are strings, integers, floats,
+// bools, time.Time, time.Duration, types implementing
+// encoding.TextUnmarshaler/TextMarshaler, and pointers to any of these.
+// Blank cells leave fields at their zero value (nil for pointers).
+
+var ErrColumnNotFound = errors.New("column not found")
+var ErrBadScanTarget = errors.New("scan target must be a pointer to a struct or a slice of structs")
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	textUnmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type fieldMap struct {
+	index     int   // field index in the struct
+	column    int64 // column in the sheet, -1 if missing
+	name      string
+	omitEmpty bool
+}
+
+// fieldMaps matches the fields of struct type typ to the header row.
+func (sstable *SSTable) fieldMaps(typ reflect.Type) (fields []fieldMap, err error) {
+	columns := map[string]int64{} // -1 for a header that appears twice
+	for i, hdr := range sstable.GetHeaders() {
+		if hdr == "" {
+			continue
+		}
+		if _, dup := columns[hdr]; dup {
+			columns[hdr] = -1
+			continue
+		}
+		columns[hdr] = int64(i)
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("ssdb")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fm := fieldMap{
+			index:     i,
+			column:    -1,
+			name:      name,
+			omitEmpty: opts == "omitempty",
+		}
+		col, ok := columns[name]
+		switch {
+		case ok && col < 0:
+			return nil, fmt.Errorf("%s: %q: %w", sstable.sheetName, name, ErrDuplicateColumnKey)
+		case ok:
+			fm.column = col
+		case !fm.omitEmpty:
+			return nil, fmt.Errorf("%s: %q: %w", sstable.sheetName, name, ErrColumnNotFound)
+		}
+		fields = append(fields, fm)
+	}
+	return //
+}
+
+// Scan fills dst, a pointer to a slice of structs or of struct pointers,
+// with one element per non-blank row below the header.
+func (sstable *SSTable) Scan(dst any) (err error) {
+	var rows []*ssdb.Row
+	sstable.currentSheet().RowIter(func(row *ssdb.Row) {
+		if row.N > 0 && !row.IsBlank() {
+			rows = append(rows, row)
+		}
+	})
+	return sstable.scanRows(rows, dst)
+}
+
+// scanRows fills dst, as for Scan, with one element per row.
+func (sstable *SSTable) scanRows(rows []*ssdb.Row, dst any) (err error) {
+	slice := reflect.ValueOf(dst)
+	if slice.Kind() != reflect.Pointer || slice.Elem().Kind() != reflect.Slice {
+		return ErrBadScanTarget
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if elemType.Kind() == reflect.Pointer {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return ErrBadScanTarget
+	}
+	fields, err := sstable.fieldMaps(structType)
+	if err != nil {
+		return err
+	}
+	res := reflect.MakeSlice(slice.Type(), 0, len(rows))
+	for _, row := range rows {
+		elem := reflect.New(structType)
+		if err = scanRow(row, fields, elem.Elem()); err != nil {
+			return err
+		}
+		if elemType.Kind() != reflect.Pointer {
+			elem = elem.Elem()
+		}
+		res = reflect.Append(res, elem)
+	}
+	slice.Set(res)
+	return nil
+}
+
+// ScanRow fills dst, a pointer to a struct, from row.
+func (sstable *SSTable) ScanRow(row *ssdb.Row, dst any) (err error) {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Pointer || val.Elem().Kind() != reflect.Struct {
+		return ErrBadScanTarget
+	}
+	fields, err := sstable.fieldMaps(val.Elem().Type())
+	if err != nil {
+		return err
+	}
+	return scanRow(row, fields, val.Elem())
+}
+
+func scanRow(row *ssdb.Row, fields []fieldMap, dst reflect.Value) error {
+	for _, fm := range fields {
+		if fm.column < 0 {
+			continue
+		}
+		err := scanCell(row.GetCellN(fm.column), dst.Field(fm.index))
+		if err != nil {
+			return fmt.Errorf("%s: %w", fm.name, err)
+		}
+	}
+	return nil
+}
+
+func scanCell(cell *ssdb.Cell, dst reflect.Value) (err error) {
+	if cell.IsBlank() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		ptr := reflect.New(dst.Type().Elem())
+		if err = scanCell(cell, ptr.Elem()); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+	// time.Time is a TextUnmarshaler too, but only for RFC 3339
+	switch dst.Type() {
+	case timeType:
+		t, err := cell.GetTime()
+		dst.Set(reflect.ValueOf(t))
+		return err
+	case durationType:
+		d, err := cell.GetDuration()
+		dst.SetInt(int64(d))
+		return err
+	}
+	if dst.Addr().Type().Implements(textUnmarshalType) {
+		return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(cell.GetString()))
+	}
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(cell.GetString())
+	case reflect.Bool:
+		b, err := cell.GetBool()
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := cell.GetInt()
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(n) {
+			return fmt.Errorf("%s: %d overflows %s: %w", cell.Address(), n, dst.Type(), ssdb.ErrCellType)
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := cell.GetInt()
+		if err != nil {
+			return err
+		}
+		if n < 0 || dst.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%s: %d overflows %s: %w", cell.Address(), n, dst.Type(), ssdb.ErrCellType)
+		}
+		dst.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := cell.GetFloat()
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", dst.Type())
+	}
+	return nil
+}
+
+// RowValues converts src, a struct, a pointer to one, or a slice of
+// either, to rows aligned with the header, ready for Updater.Update.
+// Columns with no field, and zero omitempty fields, come out as ssdb.Skip,
+// so the Updater leaves those cells as they are.
+func (sstable *SSTable) RowValues(src any) (vals [][]any, err error) {
+	val := reflect.Indirect(reflect.ValueOf(src))
+	rows := []reflect.Value{val}
+	if val.Kind() == reflect.Slice {
+		rows = rows[:0]
+		for i := 0; i < val.Len(); i++ {
+			rows = append(rows, reflect.Indirect(val.Index(i)))
+		}
+	}
+	width := int64(len(sstable.GetHeaders()))
+	var fields []fieldMap
+	for _, row := range rows {
+		if row.Kind() != reflect.Struct {
+			return nil, ErrBadScanTarget
+		}
+		if fields == nil {
+			fields, err = sstable.fieldMaps(row.Type())
+			if err != nil {
+				return nil, err
+			}
+		}
+		line := make([]any, width)
+		for i := range line {
+			line[i] = ssdb.Skip
+		}
+		for _, fm := range fields {
+			if fm.column < 0 {
+				continue
+			}
+			field := row.Field(fm.index)
+			if fm.omitEmpty && field.IsZero() {
+				continue
+			}
+			line[fm.column], err = cellValue(field)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", fm.name, err)
+			}
+		}
+		vals = append(vals, line)
+	}
+	return //
+}
+
+// cellValue converts a field to a value for the Updater.
+func cellValue(field reflect.Value) (any, error) {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return "", nil
+		}
+		field = field.Elem()
+	}
+	switch field.Type() {
+	case timeType:
+		return field.Interface(), nil // written as a date-time value
+	case durationType:
+		d := time.Duration(field.Int()).Round(time.Second)
+		sign := ""
+		if d < 0 {
+			sign, d = "-", -d
+		}
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, int64(d.Hours()), int64(d.Minutes())%60, int64(d.Seconds())%60), nil
+	}
+	if field.Type().Implements(textMarshalType) {
+		text, err := field.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		return field.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), nil
+	}
+	return nil, fmt.Errorf("unsupported field type %s", field.Type())
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clucia/ssdb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sheets/v4"
+)
+
+// testTable builds a table from cell text, without a spreadsheet.
+func testTable(rows [][]string) *SSTable {
+	grid := &sheets.GridData{}
+	for _, row := range rows {
+		rowData := &sheets.RowData{}
+		for _, text := range row {
+			rowData.Values = append(rowData.Values, &sheets.CellData{FormattedValue: text})
+		}
+		grid.RowData = append(grid.RowData, rowData)
+	}
+	return &SSTable{
+		sheetName: "Members",
+		sheet: &ssdb.Sheet{Sheet: &sheets.Sheet{
+			Properties: &sheets.SheetProperties{Title: "Members"},
+			Data:       []*sheets.GridData{grid},
+		}},
+	}
+}
+
+type member struct {
+	Name    string        `ssdb:"Full Name"`
+	Dues    float64       `ssdb:"Dues Paid,omitempty"`
+	Visits  int           `ssdb:"Visits"`
+	Active  *bool         `ssdb:"Active"`
+	Joined  time.Time     `ssdb:"Joined"`
+	Session time.Duration `ssdb:"Session,omitempty"`
+	Phone   string        `ssdb:"Phone,omitempty"` // no such column
+	Notes   string        `ssdb:"-"`
+}
+
+func TestScan(t *testing.T) {
+	table := testTable([][]string{
+		{"Full Name", "Dues Paid", "Visits", "Active", "Joined", "Session", "Comment"},
+		{"Ann", "$1,250.50", "3", "yes", "2025-07-04", "1:30:00", "x"},
+		{},
+		{"Bob", "", "0", "", "7/5/2025"},
+	})
+	var members []member
+	assert.NoError(t, table.Scan(&members))
+	assert.Len(t, members, 2)
+	assert.Equal(t, "Ann", members[0].Name)
+	assert.Equal(t, 1250.5, members[0].Dues)
+	assert.Equal(t, 3, members[0].Visits)
+	assert.True(t, *members[0].Active)
+	assert.Equal(t, time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC), members[0].Joined)
+	assert.Equal(t, 90*time.Minute, members[0].Session)
+	assert.Nil(t, members[1].Active)
+	assert.Equal(t, 5, members[1].Joined.Day())
+
+	var ptrs []*member
+	assert.NoError(t, table.Scan(&ptrs))
+	assert.Equal(t, "Bob", ptrs[1].Name)
+
+	var m member
+	assert.NoError(t, table.ScanRow(table.sheet.GetRowN(3), &m))
+	assert.Equal(t, "Bob", m.Name)
+
+	bad := testTable([][]string{{"Full Name", "Visits", "Dues Paid", "Active", "Joined"}, {"Cy", "many"}})
+	err := bad.Scan(&members)
+	assert.ErrorIs(t, err, ssdb.ErrCellType)
+	assert.ErrorContains(t, err, "Members!B2")
+
+	var wrong []struct {
+		Missing string
+	}
+	assert.ErrorIs(t, table.Scan(&wrong), ErrColumnNotFound)
+	assert.ErrorIs(t, table.Scan(members), ErrBadScanTarget)
+
+	// a repeated header only matters to a field that maps to it
+	notes := testTable([][]string{{"Full Name", "Notes", "Notes"}, {"Dee", "a", "b"}})
+	var names []struct {
+		Name string `ssdb:"Full Name"`
+	}
+	assert.NoError(t, notes.Scan(&names))
+	assert.Equal(t, "Dee", names[0].Name)
+	var withNotes []struct {
+		Notes string
+	}
+	assert.ErrorIs(t, notes.Scan(&withNotes), ErrDuplicateColumnKey)
+}
+
+func TestRowValues(t *testing.T) {
+	table := testTable([][]string{{"Full Name", "Dues Paid", "Visits", "Active", "Joined", "Session", "Comment"}})
+	yes := true
+	joined := time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC)
+	vals, err := table.RowValues(member{Name: "Ann", Visits: 3, Active: &yes, Joined: joined, Session: 90 * time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]any{{"Ann", ssdb.Skip, int64(3), true, joined, "1:30:00", ssdb.Skip}}, vals)
+
+	vals, err = table.RowValues([]*member{{Name: "Ann"}, {Name: "Bob", Dues: 5}})
+	assert.NoError(t, err)
+	assert.Len(t, vals, 2)
+	assert.Equal(t, 5.0, vals[1][1])
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/clucia/ssdb"
+)
+
+// Column types for Schema. The empty type accepts any text.
+const (
+	TypeInt      = "int"
+	TypeFloat    = "float"
+	TypeDecimal  = "decimal"
+	TypeBool     = "bool"
+	TypeDate     = "date"
+	TypeDuration = "duration"
+)
+
+// Schema declares what a table's columns should hold. It can be built in
+// code or loaded from JSON:
+//
+//	{"columns": [
+//		{"name": "ID", "required": true, "nonBlank": true, "unique": true, "pattern": "M[0-9]{4}"},
+//		{"name": "Status", "oneOf": ["active", "lapsed"]},
+//		{"name": "Joined", "type": "date"}
+//	]}
+type Schema struct {
+	Columns []Column `json:"columns"`
+}
+
+// Column holds the rules for one column. Type, OneOf and Pattern only
+// apply to non-blank cells.
+type Column struct {
+	Name     string   `json:"name"`
+	Required bool     `json:"required,omitempty"` // the header must be present
+	Type     string   `json:"type,omitempty"`     // one of the Type constants
+	NonBlank bool     `json:"nonBlank,omitempty"`
+	Unique   bool     `json:"unique,omitempty"`
+	OneOf    []string `json:"oneOf,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"` // must match the whole cell
+	pattern  *regexp.Regexp
+}
+
+// Violation is one way the table breaks its schema. Address is the cell,
+// or the header row for a problem with the columns themselves.
+type Violation struct {
+	Sheet   string
+	Address string
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Address + ": " + v.Message
+}
+
+// SetSchema sets the schema Validate checks. It fails if the schema itself
+// is broken: an unknown type or a bad pattern.
+func (sstable *SSTable) SetSchema(schema *Schema) (err error) {
+	for i := range schema.Columns {
+		col := &schema.Columns[i]
+		switch col.Type {
+		case "", TypeInt, TypeFloat, TypeDecimal, TypeBool, TypeDate, TypeDuration:
+		default:
+			return fmt.Errorf("%s: column %q: unknown type %q", sstable.sheetName, col.Name, col.Type)
+		}
+		col.pattern = nil
+		if col.Pattern != "" {
+			col.pattern, err = regexp.Compile("^(?:" + col.Pattern + ")$")
+			if err != nil {
+				return fmt.Errorf("%s: column %q: %w", sstable.sheetName, col.Name, err)
+			}
+		}
+	}
+	sstable.schema = schema
+	return nil
+}
+
+// Validate checks every non-blank row of the table against its schema and
+// returns all the violations, in sheet order. A table without a schema has
+// none.
+func (sstable *SSTable) Validate() (res []Violation) {
+	if sstable.schema == nil {
+		return nil
+	}
+	violation := func(address, format string, args ...any) {
+		res = append(res, Violation{
+			Sheet:   sstable.sheetName,
+			Address: address,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	headerRow := ssdb.FormatA1(sstable.sheetName, ssdb.GenRange(0, 0, ssdb.Unbounded, 0, 1))
+	headers := sstable.GetHeaders()
+	type checked struct {
+		*Column
+		index int64
+		seen  map[string]string // value to the address it was first seen at
+	}
+	var columns []checked
+	for i := range sstable.schema.Columns {
+		col := &sstable.schema.Columns[i]
+		index := int64(slices.Index(headers, col.Name))
+		switch {
+		case index < 0 && col.Required:
+			violation(headerRow, "missing column %q", col.Name)
+		case index < 0:
+		case slices.Index(headers[index+1:], col.Name) >= 0:
+			violation(headerRow, "column %q appears more than once", col.Name)
+		default:
+			columns = append(columns, checked{Column: col, index: index, seen: map[string]string{}})
+		}
+	}
+	sheet := sstable.currentSheet()
+	sheet.RowIter(func(row *ssdb.Row) {
+		if row.N == 0 || row.IsBlank() {
+			return
+		}
+		for _, col := range columns {
+			address := sheet.NewDBRange(row.N, col.index, 1, 1).String()
+			cell := row.GetCellN(col.index)
+			text := cell.GetString()
+			if text == "" {
+				if col.NonBlank {
+					violation(address, "%s is blank", col.Name)
+				}
+				continue
+			}
+			if err := checkType(cell, col.Type); err != nil {
+				violation(address, "%s: %q is not a valid %s", col.Name, text, col.Type)
+			}
+			if len(col.OneOf) > 0 && !slices.Contains(col.OneOf, text) {
+				violation(address, "%s: %q is not one of %q", col.Name, text, col.OneOf)
+			}
+			if col.pattern != nil && !col.pattern.MatchString(text) {
+				violation(address, "%s: %q does not match %s", col.Name, text, col.Pattern)
+			}
+			if col.Unique {
+				if first, dup := col.seen[text]; dup {
+					violation(address, "%s: %q is already used at %s", col.Name, text, first)
+				} else {
+					col.seen[text] = address
+				}
+			}
+		}
+	})
+	return //
+}
+
+func checkType(cell *ssdb.Cell, typ string) (err error) {
+	switch typ {
+	case TypeInt:
+		_, err = cell.GetInt()
+	case TypeFloat:
+		_, err = cell.GetFloat()
+	case TypeDecimal:
+		_, err = cell.GetDecimal()
+	case TypeBool:
+		_, err = cell.GetBool()
+	case TypeDate:
+		_, err = cell.GetTime()
+	case TypeDuration:
+		_, err = cell.GetDuration()
+	}
+	return //
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	table := testTable([][]string{
+		{"ID", "Status", "Joined", "Dues"},
+		{"M0001", "active", "2025-07-04", "10"},
+		{"M0002", "gone", "someday", "n/a"},
+		{},
+		{"M0001", "lapsed"},
+		{"", "active", "", "5"},
+	})
+	assert.Nil(t, table.Validate())
+
+	var schema Schema
+	assert.NoError(t, json.Unmarshal([]byte(`{"columns": [
+		{"name": "ID", "required": true, "nonBlank": true, "unique": true, "pattern": "M[0-9]{4}"},
+		{"name": "Status", "oneOf": ["active", "lapsed"]},
+		{"name": "Joined", "type": "date"},
+		{"name": "Dues", "type": "decimal"},
+		{"name": "Email", "required": true},
+		{"name": "Phone"}
+	]}`), &schema))
+	assert.NoError(t, table.SetSchema(&schema))
+
+	var got []string
+	for _, v := range table.Validate() {
+		assert.Equal(t, "Members", v.Sheet)
+		got = append(got, v.String())
+	}
+	assert.Equal(t, []string{
+		`Members!1:1: missing column "Email"`,
+		`Members!B3: Status: "gone" is not one of ["active" "lapsed"]`,
+		`Members!C3: Joined: "someday" is not a valid date`,
+		`Members!D3: Dues: "n/a" is not a valid decimal`,
+		`Members!A5: ID: "M0001" is already used at Members!A2`,
+		`Members!A6: ID is blank`,
+	}, got)
+
+	assert.Error(t, table.SetSchema(&Schema{Columns: []Column{{Name: "ID", Type: "number"}}}))
+	assert.Error(t, table.SetSchema(&Schema{Columns: []Column{{Name: "ID", Pattern: "("}}}))
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/clucia/ssdb"
+)
+
+// Select runs a query against the cached spreadsheet and returns a header
+// row followed by the result rows:
+//
+//	res, err := sstable.Select(db, "SELECT Name, Email FROM Members WHERE Enable = 'yes' ORDER BY Name LIMIT 10")
+//	res, err = sstable.Select(db, `SELECT Event, COUNT(*) AS Seats, SUM(Amount) FROM Payments GROUP BY Event`)
+//
+// Sheets are tables and header cells are columns, matched exactly; quote
+// names that aren't plain words with double quotes, backquotes or [].
+// Comparisons work as for Query.Where: against a number or DATE
+// '2025-01-31' a cell is read as a number or a date. LIKE takes % and _
+// wildcards, and IS NULL matches blank cells. Columns come back as text,
+// and aggregates as for GroupBy.
+func Select(db *ssdb.SSDB, query string) (res [][]any, err error) {
+	stmt, err := parseSelect(query)
+	if err != nil {
+		return nil, err
+	}
+	table, err := Open(db, stmt.from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", stmt.from, err)
+	}
+	return stmt.run(table)
+}
+
+type selectStmt struct {
+	items   []selectItem
+	from    string
+	where   sqlExpr // nil for all rows
+	groupBy []string
+	orderBy []sqlOrder
+	limit   int // -1 for no limit
+}
+
+type selectItem struct {
+	star   bool
+	fn     string // aggregate function, "" for a plain column
+	column string // "*" for COUNT(*)
+	alias  string
+	index  int64 // the column, once bound
+}
+
+func (item selectItem) label() string {
+	switch {
+	case item.alias != "":
+		return item.alias
+	case item.fn != "":
+		return Aggregate{fn: item.fn, column: item.column}.String()
+	}
+	return item.column
+}
+
+type sqlOrder struct {
+	name string
+	desc bool
+}
+
+// sqlExpr is a WHERE condition. bind looks up its columns in the table.
+type sqlExpr interface {
+	bind(table *SSTable) error
+	eval(row *ssdb.Row) bool
+}
+
+type sqlAnd struct{ left, right sqlExpr }
+type sqlOr struct{ left, right sqlExpr }
+type sqlNot struct{ expr sqlExpr }
+
+type sqlCond struct {
+	name   string
+	column int64
+	op     Op
+	val    any
+	like   *regexp.Regexp // for LIKE, instead of op and val
+}
+
+func (e sqlAnd) bind(table *SSTable) error {
+	if err := e.left.bind(table); err != nil {
+		return err
+	}
+	return e.right.bind(table)
+}
+
+func (e sqlOr) bind(table *SSTable) error {
+	if err := e.left.bind(table); err != nil {
+		return err
+	}
+	return e.right.bind(table)
+}
+
+func (e sqlNot) bind(table *SSTable) error { return e.expr.bind(table) }
+
+func (e *sqlCond) bind(table *SSTable) (err error) {
+	if e.column, err = table.columnNamed(e.name); err != nil {
+		return err
+	}
+	if e.val, err = sqlValue(table, e.val); err != nil {
+		return err
+	}
+	if list, ok := e.val.([]any); ok {
+		for i := range list {
+			if list[i], err = sqlValue(table, list[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sqlValue reads a DATE literal in the table's locale.
+func sqlValue(table *SSTable, val any) (any, error) {
+	date, ok := val.(sqlDate)
+	if !ok {
+		return val, nil
+	}
+	t, ok := table.DB.Locale().ParseTime(string(date))
+	if !ok {
+		return nil, fmt.Errorf("%w: bad date '%s'", ErrSQLSyntax, date)
+	}
+	return t, nil
+}
+
+func (e sqlAnd) eval(row *ssdb.Row) bool { return e.left.eval(row) && e.right.eval(row) }
+func (e sqlOr) eval(row *ssdb.Row) bool  { return e.left.eval(row) || e.right.eval(row) }
+func (e sqlNot) eval(row *ssdb.Row) bool { return !e.expr.eval(row) }
+
+func (e *sqlCond) eval(row *ssdb.Row) bool {
+	cell := row.GetCellN(e.column)
+	if e.like != nil {
+		return e.like.MatchString(cell.GetString())
+	}
+	return match(cell, e.op, e.val)
+}
+
+func (stmt *selectStmt) run(table *SSTable) (res [][]any, err error) {
+	var items []selectItem
+	grouped := len(stmt.groupBy) > 0
+	for _, item := range stmt.items {
+		switch {
+		case item.star:
+			for i, hdr := range table.GetHeaders() {
+				if hdr != "" {
+					items = append(items, selectItem{column: hdr, index: int64(i)})
+				}
+			}
+			continue
+		case item.fn == "COUNT" && item.column == "*":
+			item.index = -1
+		default:
+			if item.index, err = table.columnNamed(item.column); err != nil {
+				return nil, err
+			}
+		}
+		grouped = grouped || item.fn != ""
+		items = append(items, item)
+	}
+	if stmt.where != nil {
+		if err = stmt.where.bind(table); err != nil {
+			return nil, err
+		}
+	}
+	var rows []*ssdb.Row
+	table.currentSheet().RowIter(func(row *ssdb.Row) {
+		if row.N > 0 && !row.IsBlank() && (stmt.where == nil || stmt.where.eval(row)) {
+			rows = append(rows, row)
+		}
+	})
+	header := make([]any, len(items))
+	for i, item := range items {
+		header[i] = item.label()
+	}
+	if grouped {
+		res, err = stmt.group(table, items, rows)
+	} else {
+		res, err = stmt.project(table, items, rows)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stmt.limit >= 0 && len(res) > stmt.limit {
+		res = res[:stmt.limit]
+	}
+	return append([][]any{header}, res...), nil
+}
+
+// project sorts rows, which may be by columns that aren't selected, and
+// picks the selected columns out of them.
+func (stmt *selectStmt) project(table *SSTable, items []selectItem, rows []*ssdb.Row) (res [][]any, err error) {
+	var order []ordering
+	for _, o := range stmt.orderBy {
+		i := slices.IndexFunc(items, func(item selectItem) bool { return item.label() == o.name })
+		col := int64(-1)
+		if i >= 0 {
+			col = items[i].index
+		} else if col, err = table.columnNamed(o.name); err != nil {
+			return nil, err
+		}
+		order = append(order, ordering{column: col, desc: o.desc})
+	}
+	sortRows(rows, order)
+	for _, row := range rows {
+		line := make([]any, len(items))
+		for i, item := range items {
+			line[i] = row.GetCellN(item.index).GetString()
+		}
+		res = append(res, line)
+	}
+	return res, nil
+}
+
+// group makes one result row per distinct value of the GROUP BY columns,
+// in order of first appearance, or a single row without GROUP BY.
+func (stmt *selectStmt) group(table *SSTable, items []selectItem, rows []*ssdb.Row) (res [][]any, err error) {
+	var groupCols []int64
+	for _, name := range stmt.groupBy {
+		col, err := table.columnNamed(name)
+		if err != nil {
+			return nil, err
+		}
+		groupCols = append(groupCols, col)
+	}
+	for _, item := range items {
+		if item.fn == "" && !slices.Contains(groupCols, item.index) {
+			return nil, fmt.Errorf("%w: %q is neither grouped nor aggregated", ErrSQLSyntax, item.column)
+		}
+	}
+	groups := groupRows(rows, groupCols)
+	if len(groupCols) == 0 && len(groups) == 0 {
+		groups = [][]*ssdb.Row{nil} // aggregates over no rows
+	}
+	for _, group := range groups {
+		line := make([]any, len(items))
+		for i, item := range items {
+			if item.fn == "" {
+				line[i] = group[0].GetCellN(item.index).GetString()
+				continue
+			}
+			agg := Aggregate{fn: item.fn, column: item.column}
+			if line[i], err = agg.compute(group, item.index); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, line)
+	}
+	var order []int
+	for _, o := range stmt.orderBy {
+		i := slices.IndexFunc(items, func(item selectItem) bool { return item.label() == o.name })
+		if i < 0 {
+			return nil, fmt.Errorf("%s: ORDER BY %q is not in the result: %w", table.sheetName, o.name, ErrColumnNotFound)
+		}
+		order = append(order, i)
+	}
+	locale := table.DB.Locale()
+	slices.SortStableFunc(res, func(a, b []any) int {
+		for n, i := range order {
+			c := compareResults(locale, a[i], b[i])
+			if stmt.orderBy[n].desc && a[i] != nil && b[i] != nil {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return res, nil
+}
+
+// compareResults orders result values as compareCells orders cells:
+// numbers, dates, text, then nil.
+func compareResults(locale *ssdb.Locale, a, b any) int {
+	rank := func(v any) (r int, f float64, t time.Time, s string) {
+		switch v := v.(type) {
+		case nil:
+			return 3, 0, t, ""
+		case int64:
+			return 0, float64(v), t, ""
+		case float64:
+			return 0, v, t, ""
+		case time.Time:
+			return 1, 0, v, ""
+		}
+		s = fmt.Sprint(v)
+		if f, _, ok := locale.ParseNumber(s); ok {
+			return 0, f, t, s
+		}
+		if t, ok := locale.ParseTime(s); ok {
+			return 1, 0, t, s
+		}
+		return 2, 0, t, s
+	}
+	ra, fa, ta, sa := rank(a)
+	rb, fb, tb, sb := rank(b)
+	switch {
+	case ra != rb:
+		return cmp.Compare(ra, rb)
+	case ra == 0:
+		return cmp.Compare(fa, fb)
+	case ra == 1:
+		return ta.Compare(tb)
+	}
+	return strings.Compare(sa, sb)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelect(t *testing.T) {
+	table := testTable([][]string{
+		{"Name", "Email", "Enable", "Event", "Amount", "Date"},
+		{"Dee", "dee@example.org", "yes", "Gala", "40", "2025-03-01"},
+		{"Ann", "ann@example.org", "yes", "Picnic", "9.5", "2025-01-15"},
+		{"Cy", "", "no", "Gala", "35", "2025-02-10"},
+		{},
+		{"Bob", "bob@example.org", "yes", "Gala", "", "2024-12-30"},
+	})
+	query := func(sql string) [][]any {
+		stmt, err := parseSelect(sql)
+		if !assert.NoError(t, err, sql) {
+			return nil
+		}
+		res, err := stmt.run(table)
+		assert.NoError(t, err, sql)
+		return res
+	}
+	assert.Equal(t, [][]any{
+		{"Name", "Email"},
+		{"Ann", "ann@example.org"},
+		{"Bob", "bob@example.org"},
+	}, query("SELECT Name, Email FROM Members WHERE Enable = 'yes' ORDER BY Name LIMIT 2"))
+
+	assert.Equal(t, [][]any{{"Name"}, {"Dee"}, {"Cy"}, {"Ann"}, {"Bob"}},
+		query("select Name from Members order by Amount desc"))
+	assert.Equal(t, [][]any{{"Name"}}, query("SELECT Name FROM Members LIMIT 0"))
+	assert.Equal(t, [][]any{{"Who"}, {"Cy"}, {"Dee"}},
+		query("SELECT Name AS Who FROM Members WHERE Amount > 10 AND (Enable = 'no' OR Email LIKE 'd%') ORDER BY Date"))
+	assert.Equal(t, [][]any{{"Name"}, {"Cy"}},
+		query("SELECT Name FROM Members WHERE Email IS NULL AND NOT Name IN ('Ann', 'Bob')"))
+	assert.Equal(t, [][]any{{"Name"}, {"Bob"}},
+		query("SELECT Name FROM Members WHERE [Date] < DATE '2025-01-01'"))
+
+	assert.Equal(t, [][]any{
+		{"Event", "Seats", "SUM(Amount)", "MIN(Date)"},
+		{"Picnic", int64(1), 9.5, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"Gala", int64(3), 75.0, time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)},
+	}, query("SELECT Event, COUNT(*) AS Seats, SUM(Amount), MIN(Date) FROM Members GROUP BY Event ORDER BY Seats"))
+	assert.Equal(t, [][]any{{"COUNT(Amount)", "AVG(Amount)", "MAX(Name)"}, {int64(3), 84.5 / 3, "Dee"}},
+		query("SELECT COUNT(Amount), AVG(Amount), MAX(Name) FROM Members"))
+	assert.Equal(t, [][]any{{"COUNT(*)", "AVG(Amount)"}, {int64(0), nil}},
+		query("SELECT COUNT(*), AVG(Amount) FROM Members WHERE Name = 'nobody'"))
+	assert.Len(t, query("SELECT * FROM Members")[0], 6)
+
+	for _, bad := range []string{
+		"SELECT FROM Members",
+		"SELECT Name FROM Members WHERE",
+		"SELECT Name FROM Members WHERE Name = 'x",
+		"SELECT Name FROM Members LIMIT 2.5",
+		"SELECT Name, Nope FROM Members",
+		"SELECT Name, COUNT(*) FROM Members GROUP BY Event",
+		"SELECT Median(Amount) FROM Members",
+	} {
+		stmt, err := parseSelect(bad)
+		if err == nil {
+			_, err = stmt.run(table)
+		}
+		assert.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrSQLSyntax = errors.New("SQL syntax error")
+
+type sqlToken struct {
+	kind byte // 'w' word, 'q' quoted name, 's' string, 'n' number, 'p' punctuation, 0 at the end
+	text string
+	pos  int
+}
+
+func (tok sqlToken) String() string {
+	switch tok.kind {
+	case 0:
+		return "end of query"
+	case 's':
+		return "'" + tok.text + "'"
+	}
+	return tok.text
+}
+
+// lexSQL splits src into tokens. Names with spaces are quoted with double
+// quotes, backticks or brackets; strings with single quotes, doubled to
+// escape.
+func lexSQL(src string) (toks []sqlToken, err error) {
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		start := i
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '\'':
+			var text strings.Builder
+			for i++; ; i++ {
+				if i >= len(src) {
+					return nil, fmt.Errorf("%w: unterminated string at %d", ErrSQLSyntax, start)
+				}
+				if src[i] == '\'' {
+					if i+1 < len(src) && src[i+1] == '\'' {
+						i++
+					} else {
+						break
+					}
+				}
+				text.WriteByte(src[i])
+			}
+			i++
+			toks = append(toks, sqlToken{kind: 's', text: text.String(), pos: start})
+		case c == '"' || c == '`' || c == '[':
+			end := map[rune]byte{'"': '"', '`': '`', '[': ']'}[c]
+			n := strings.IndexByte(src[i+1:], end)
+			if n < 0 {
+				return nil, fmt.Errorf("%w: unterminated name at %d", ErrSQLSyntax, start)
+			}
+			toks = append(toks, sqlToken{kind: 'q', text: src[i+1 : i+1+n], pos: start})
+			i += n + 2
+		case unicode.IsDigit(c) || c == '.' || c == '-' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1])):
+			for i++; i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.'); i++ {
+			}
+			toks = append(toks, sqlToken{kind: 'n', text: src[start:i], pos: start})
+		case c == '_' || c >= 0x80 || unicode.IsLetter(c):
+			for i++; i < len(src) && (src[i] == '_' || src[i] >= 0x80 || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))); i++ {
+			}
+			toks = append(toks, sqlToken{kind: 'w', text: src[start:i], pos: start})
+		default:
+			i++
+			if op := src[start:min(i+1, len(src))]; op == "<=" || op == ">=" || op == "<>" || op == "!=" {
+				i++
+			} else if !strings.ContainsRune("(),*=<>", c) {
+				return nil, fmt.Errorf("%w: unexpected %q at %d", ErrSQLSyntax, c, start)
+			}
+			toks = append(toks, sqlToken{kind: 'p', text: src[start:i], pos: start})
+		}
+	}
+	return toks, nil
+}
+
+var sqlKeywords = []string{
+	"SELECT", "FROM", "WHERE", "GROUP", "ORDER", "BY", "ASC", "DESC", "LIMIT",
+	"AND", "OR", "NOT", "IN", "LIKE", "IS", "NULL", "AS", "TRUE", "FALSE",
+}
+
+var sqlAggregates = []string{"COUNT", "SUM", "AVG", "MIN", "MAX"}
+
+type sqlParser struct {
+	toks []sqlToken
+	pos  int
+}
+
+func (p *sqlParser) peek() sqlToken {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return sqlToken{}
+}
+
+func (p *sqlParser) next() sqlToken {
+	tok := p.peek()
+	p.pos = min(p.pos+1, len(p.toks))
+	return tok
+}
+
+// keyword consumes the next token if it is the word kw, in any case.
+func (p *sqlParser) keyword(kw string) bool {
+	if tok := p.peek(); tok.kind == 'w' && strings.EqualFold(tok.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// punct consumes the next token if it is the punctuation text.
+func (p *sqlParser) punct(text string) bool {
+	if tok := p.peek(); tok.kind == 'p' && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) errorf(want string) error {
+	tok := p.peek()
+	return fmt.Errorf("%w: expected %s, found %s at %d", ErrSQLSyntax, want, tok, tok.pos)
+}
+
+func (p *sqlParser) expect(kw string) error {
+	if !p.keyword(kw) {
+		return p.errorf(kw)
+	}
+	return nil
+}
+
+// name reads a header or sheet name: a word that isn't a keyword, or a
+// quoted name.
+func (p *sqlParser) name() (string, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == 'q':
+	case tok.kind == 'w' && !slices.ContainsFunc(sqlKeywords, func(kw string) bool { return strings.EqualFold(kw, tok.text) }):
+	default:
+		return "", p.errorf("a name")
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+func (p *sqlParser) number() (val float64, err error) {
+	tok := p.peek()
+	if tok.kind != 'n' {
+		return 0, p.errorf("a number")
+	}
+	val, err = strconv.ParseFloat(tok.text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad number %s at %d", ErrSQLSyntax, tok.text, tok.pos)
+	}
+	p.pos++
+	return val, nil
+}
+
+// literal reads a value to compare with: a string, a number, TRUE, FALSE,
+// or DATE 'text', which is kept as text for the table's locale to parse.
+func (p *sqlParser) literal() (val any, err error) {
+	switch tok := p.peek(); {
+	case tok.kind == 's':
+		p.pos++
+		return tok.text, nil
+	case tok.kind == 'n':
+		return p.number()
+	case p.keyword("TRUE"):
+		return true, nil
+	case p.keyword("FALSE"):
+		return false, nil
+	case tok.kind == 'w' && strings.EqualFold(tok.text, "DATE") && p.pos+1 < len(p.toks) && p.toks[p.pos+1].kind == 's':
+		// DATE is not reserved, as it is a common header
+		p.pos += 2
+		return sqlDate(p.toks[p.pos-1].text), nil
+	}
+	return nil, p.errorf("a value")
+}
+
+// sqlDate is a DATE literal, read as a time when the query runs.
+type sqlDate string
+
+// parseSelect parses
+//
+//	SELECT item, ... FROM sheet [WHERE cond] [GROUP BY column, ...]
+//		[ORDER BY name [ASC|DESC], ...] [LIMIT n]
+//
+// where an item is *, a column, or COUNT(*), COUNT, SUM, AVG, MIN or MAX of
+// a column, each optionally AS a name.
+func parseSelect(query string) (stmt *selectStmt, err error) {
+	toks, err := lexSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &sqlParser{toks: toks}
+	stmt = &selectStmt{limit: -1}
+	if err = p.expect("SELECT"); err != nil {
+		return nil, err
+	}
+	for {
+		item, err := p.selectItem()
+		if err != nil {
+			return nil, err
+		}
+		stmt.items = append(stmt.items, item)
+		if !p.punct(",") {
+			break
+		}
+	}
+	if err = p.expect("FROM"); err != nil {
+		return nil, err
+	}
+	if stmt.from, err = p.name(); err != nil {
+		return nil, err
+	}
+	if p.keyword("WHERE") {
+		if stmt.where, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+	if p.keyword("GROUP") {
+		if err = p.expect("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			stmt.groupBy = append(stmt.groupBy, name)
+			if !p.punct(",") {
+				break
+			}
+		}
+	}
+	if p.keyword("ORDER") {
+		if err = p.expect("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			var order sqlOrder
+			if order.name, err = p.itemName(); err != nil {
+				return nil, err
+			}
+			order.desc = p.keyword("DESC")
+			if !order.desc {
+				p.keyword("ASC")
+			}
+			stmt.orderBy = append(stmt.orderBy, order)
+			if !p.punct(",") {
+				break
+			}
+		}
+	}
+	if p.keyword("LIMIT") {
+		n, err := p.number()
+		if err != nil || n < 0 || n != float64(int(n)) {
+			return nil, fmt.Errorf("%w: LIMIT needs a whole number", ErrSQLSyntax)
+		}
+		stmt.limit = int(n)
+	}
+	if tok := p.peek(); tok.kind != 0 {
+		return nil, p.errorf("end of query")
+	}
+	return stmt, nil
+}
+
+// itemName reads a name for ORDER BY, which may also be an aggregate as
+// written in the select list, e.g. COUNT(*).
+func (p *sqlParser) itemName() (string, error) {
+	fn, arg, ok, err := p.aggregate()
+	switch {
+	case err != nil:
+		return "", err
+	case ok:
+		return Aggregate{fn: fn, column: arg}.String(), nil
+	}
+	return p.name()
+}
+
+// aggregate reads FN(column) or COUNT(*), if that is what comes next.
+func (p *sqlParser) aggregate() (fn, arg string, ok bool, err error) {
+	tok := p.peek()
+	if tok.kind != 'w' || p.pos+1 >= len(p.toks) || p.toks[p.pos+1].text != "(" {
+		return "", "", false, nil
+	}
+	fn = strings.ToUpper(tok.text)
+	if !slices.Contains(sqlAggregates, fn) {
+		return "", "", false, fmt.Errorf("%w: unknown function %s at %d", ErrSQLSyntax, tok.text, tok.pos)
+	}
+	p.pos += 2
+	if fn == "COUNT" && p.punct("*") {
+		arg = "*"
+	} else if arg, err = p.name(); err != nil {
+		return "", "", false, err
+	}
+	if !p.punct(")") {
+		return "", "", false, p.errorf(")")
+	}
+	return fn, arg, true, nil
+}
+
+func (p *sqlParser) selectItem() (item selectItem, err error) {
+	if p.punct("*") {
+		return selectItem{star: true}, nil
+	}
+	var ok bool
+	item.fn, item.column, ok, err = p.aggregate()
+	switch {
+	case err != nil:
+		return item, err
+	case !ok:
+		if item.column, err = p.name(); err != nil {
+			return item, err
+		}
+	}
+	if p.keyword("AS") {
+		if item.alias, err = p.name(); err != nil {
+			return item, err
+		}
+	}
+	return item, nil
+}
+
+func (p *sqlParser) or() (expr sqlExpr, err error) {
+	expr, err = p.and()
+	for err == nil && p.keyword("OR") {
+		var right sqlExpr
+		right, err = p.and()
+		expr = sqlOr{expr, right}
+	}
+	return expr, err
+}
+
+func (p *sqlParser) and() (expr sqlExpr, err error) {
+	expr, err = p.not()
+	for err == nil && p.keyword("AND") {
+		var right sqlExpr
+		right, err = p.not()
+		expr = sqlAnd{expr, right}
+	}
+	return expr, err
+}
+
+func (p *sqlParser) not() (expr sqlExpr, err error) {
+	if p.keyword("NOT") {
+		expr, err = p.not()
+		return sqlNot{expr}, err
+	}
+	if p.punct("(") {
+		expr, err = p.or()
+		if err == nil && !p.punct(")") {
+			err = p.errorf(")")
+		}
+		return expr, err
+	}
+	return p.condition()
+}
+
+var sqlOps = map[string]Op{"=": Eq, "<>": Ne, "!=": Ne, "<": Lt, "<=": Le, ">": Gt, ">=": Ge}
+
+// condition reads column op value, column [NOT] IN (value, ...),
+// column [NOT] LIKE 'pattern' or column IS [NOT] NULL. NULL is a blank
+// cell.
+func (p *sqlParser) condition() (expr sqlExpr, err error) {
+	cond := &sqlCond{}
+	if cond.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind == 'p' {
+		if op, ok := sqlOps[tok.text]; ok {
+			p.pos++
+			cond.op = op
+			cond.val, err = p.literal()
+			return cond, err
+		}
+	}
+	if p.keyword("IS") {
+		cond.op = Blank
+		if p.keyword("NOT") {
+			cond.op = NotBlank
+		}
+		return cond, p.expect("NULL")
+	}
+	negate := p.keyword("NOT")
+	expr = cond
+	if negate {
+		expr = sqlNot{cond}
+	}
+	switch {
+	case p.keyword("IN"):
+		cond.op = In
+		if !p.punct("(") {
+			return nil, p.errorf("(")
+		}
+		var list []any
+		for {
+			val, err := p.literal()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+			if !p.punct(",") {
+				break
+			}
+		}
+		if !p.punct(")") {
+			return nil, p.errorf(")")
+		}
+		cond.val = list
+	case p.keyword("LIKE"):
+		tok := p.next()
+		if tok.kind != 's' {
+			return nil, fmt.Errorf("%w: expected a LIKE pattern at %d", ErrSQLSyntax, tok.pos)
+		}
+		cond.like = likePattern(tok.text)
+	default:
+		return nil, p.errorf("a comparison")
+	}
+	return expr, nil
+}
+
+// likePattern turns a LIKE pattern, with % for any text and _ for any
+// character, into a regexp.
+func likePattern(pattern string) *regexp.Regexp {
+	var re strings.Builder
+	re.WriteString("^(?s:")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			re.WriteString(".*")
+		case '_':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString(")$")
+	return regexp.MustCompile(re.String())
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/clucia/ssdb"
+)
+
+var ErrBlankKey = errors.New("blank key")
+var ErrKeyType = errors.New("key must be a string or an integer")
+
+// Typed is a table of T, one row per value, with the rows told apart by
+// the text in a key column. T is a struct mapped to the header as for
+// Scan; one of its fields must map to the key column, and be a string or
+// an integer so that it reads back as the text it was written as.
+//
+// Changes are queued on an Updater and happen on its Sync, after which
+// the cache (and so Get and All) sees them.
+type Typed[T any] struct {
+	*SSTable
+	keyColumn  string
+	appendLine int64            // next row Put appends to, -1 to look it up
+	appended   map[string]int64 // keys Put has appended on updater, to their rows
+	updater    *ssdb.Updater    // the updater appended is for
+}
+
+// NewTyped returns a Typed view of table keyed by keyColumn.
+func NewTyped[T any](table *SSTable, keyColumn string) (typed *Typed[T], err error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, ErrBadScanTarget
+	}
+	fields, err := table.fieldMaps(typ)
+	if err != nil {
+		return nil, err
+	}
+	key := slices.IndexFunc(fields, func(fm fieldMap) bool { return fm.name == keyColumn && fm.column >= 0 })
+	if key < 0 {
+		return nil, fmt.Errorf("%s: key %q: %w", table.sheetName, keyColumn, ErrColumnNotFound)
+	}
+	if !isKeyType(typ.Field(fields[key].index).Type) {
+		return nil, fmt.Errorf("%s: key %q: %w", table.sheetName, keyColumn, ErrKeyType)
+	}
+	err = table.AddIndex(keyColumn, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Typed[T]{
+		SSTable:    table,
+		keyColumn:  keyColumn,
+		appendLine: -1,
+	}, nil
+}
+
+// isKeyType reports whether a key field of type typ is written as the text
+// the index finds it by. Floats, times and the like come back in the
+// cell's number format, so 1250.5 could be found as "1,250.50" or not at
+// all.
+func isKeyType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return typ != durationType && !typ.Implements(textMarshalType)
+	}
+	return false
+}
+
+// OpenTyped opens sheetName as a Typed table keyed by keyColumn.
+func OpenTyped[T any](db *ssdb.SSDB, sheetName, keyColumn string) (typed *Typed[T], err error) {
+	table, err := Open(db, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	return NewTyped[T](table, keyColumn)
+}
+
+func (typed *Typed[T]) fields() (fields []fieldMap, keyCol int64, err error) {
+	fields, err = typed.fieldMaps(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, -1, err
+	}
+	for _, fm := range fields {
+		if fm.name == typed.keyColumn && fm.column >= 0 {
+			return fields, fm.column, nil
+		}
+	}
+	return nil, -1, fmt.Errorf("%s: key %q: %w", typed.sheetName, typed.keyColumn, ErrColumnNotFound)
+}
+
+// keyRows calls f for every row below the header with a non-blank key.
+func (typed *Typed[T]) keyRows(keyCol int64, f func(key string, row *ssdb.Row)) {
+	typed.currentSheet().RowIter(func(row *ssdb.Row) {
+		if row.N == 0 {
+			return
+		}
+		if key := row.GetCellN(keyCol).GetString(); key != "" {
+			f(key, row)
+		}
+	})
+}
+
+// Get returns the value stored under key. It fails with ErrLookupFailed if
+// there is none and ErrDuplicateRowKey if there is more than one.
+func (typed *Typed[T]) Get(key string) (val T, err error) {
+	row, err := typed.FindBy(typed.keyColumn, key)
+	if err != nil {
+		return val, err
+	}
+	err = typed.ScanRow(row, &val)
+	return //
+}
+
+// All returns the value of every row with a key, in sheet order. It fails
+// on the first row that doesn't convert, naming the cell.
+func (typed *Typed[T]) All() (res []T, err error) {
+	fields, keyCol, err := typed.fields()
+	if err != nil {
+		return nil, err
+	}
+	typed.keyRows(keyCol, func(key string, row *ssdb.Row) {
+		if err != nil {
+			return
+		}
+		var val T
+		if err = scanRow(row, fields, reflect.ValueOf(&val).Elem()); err == nil {
+			res = append(res, val)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Count returns the number of rows with a key.
+func (typed *Typed[T]) Count() (n int, err error) {
+	_, keyCol, err := typed.fields()
+	if err != nil {
+		return 0, err
+	}
+	typed.keyRows(keyCol, func(key string, row *ssdb.Row) {
+		n++
+	})
+	return n, nil
+}
+
+// Put stores val under its key: the row with that key is updated in place,
+// or a new row is appended. Only the columns T maps are written, and zero
+// omitempty fields are skipped, so other cells (formulas, notes for people)
+// are left alone. Putting a key again before updater is synced rewrites
+// the row queued for it.
+func (typed *Typed[T]) Put(updater *ssdb.Updater, val T) (err error) {
+	_, keyCol, err := typed.fields()
+	if err != nil {
+		return err
+	}
+	vals, err := typed.RowValues(val)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprint(vals[0][keyCol])
+	if vals[0][keyCol] == ssdb.Skip || key == "" {
+		return fmt.Errorf("%s: %s: %w", typed.sheetName, typed.keyColumn, ErrBlankKey)
+	}
+	appended := typed.appendedOn(updater)
+	line, queued := appended[key]
+	row, err := typed.FindBy(typed.keyColumn, key)
+	switch {
+	case err == nil:
+		line = row.N
+	case !errors.Is(err, ErrLookupFailed):
+		return err
+	case !queued:
+		line = typed.nextLine(updater)
+		appended[key] = line
+	}
+	updater.Update(typed.currentSheet().NewDBRange(line, 0, 1, int64(len(vals[0]))), vals)
+	return nil
+}
+
+// appendedOn returns the keys appended on updater. They are forgotten
+// once it has synced, when the cache has the rows.
+func (typed *Typed[T]) appendedOn(updater *ssdb.Updater) map[string]int64 {
+	if typed.updater != updater || updater.Len() == 0 {
+		typed.updater = updater
+		typed.appended = map[string]int64{}
+	}
+	return typed.appended
+}
+
+// nextLine returns the row for the next append. Appends queued on an
+// updater that hasn't been synced yet are not in the cache, so they are
+// counted here.
+func (typed *Typed[T]) nextLine(updater *ssdb.Updater) (line int64) {
+	typed.currentSheet().RowIter(func(row *ssdb.Row) {
+		if !row.IsBlank() {
+			line = row.N + 1
+		}
+	})
+	if updater.Len() > 0 {
+		line = max(line, typed.appendLine)
+	}
+	typed.appendLine = line + 1
+	return line
+}
+
+// Delete removes the row stored under key.
+func (typed *Typed[T]) Delete(updater *ssdb.Updater, key string) (err error) {
+	row, err := typed.FindBy(typed.keyColumn, key)
+	if err != nil {
+		return err
+	}
+	updater.DeleteRows(typed.currentSheet(), row.N, 1)
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Way To Go LLC. All rights reserved.
+//
+// This file is part of SSDB (Spreadsheet Database).
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license information.
+package sstable
+
+import (
+	"testing"
+
+	"github.com/clucia/ssdb"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	ID      string `ssdb:"ID"`
+	Name    string `ssdb:"Name"`
+	Balance int    `ssdb:"Balance"`
+}
+
+func TestTyped(t *testing.T) {
+	table := testTable([][]string{
+		{"ID", "Name", "Formula", "Balance"},
+		{"a1", "Ann", "=1", "10"},
+		{"", "no key", "", "0"},
+		{"b2", "Bob", "=2", "oops"},
+		{"c3", "Cy", "=3", "30"},
+	})
+	typed, err := NewTyped[account](table, "ID")
+	assert.NoError(t, err)
+
+	acct, err := typed.Get("c3")
+	assert.NoError(t, err)
+	assert.Equal(t, account{ID: "c3", Name: "Cy", Balance: 30}, acct)
+	_, err = typed.Get("zz")
+	assert.ErrorIs(t, err, ErrLookupFailed)
+	_, err = typed.Get("b2")
+	assert.ErrorIs(t, err, ssdb.ErrCellType)
+
+	n, err := typed.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	_, err = typed.All()
+	assert.ErrorIs(t, err, ssdb.ErrCellType)
+	assert.ErrorContains(t, err, "Members!D4") // b2's Balance
+
+	_, err = NewTyped[account](table, "Name ")
+	assert.ErrorIs(t, err, ErrColumnNotFound)
+
+	updater := (&ssdb.SSDB{}).NewUpdater()
+	assert.NoError(t, typed.Put(updater, account{ID: "a1", Name: "Ann", Balance: 11}))
+	assert.Equal(t, int64(1), updater.Len()) // Formula is written as ssdb.Skip
+	assert.NoError(t, typed.Put(updater, account{ID: "d4", Name: "Dee"}))
+	assert.NoError(t, typed.Put(updater, account{ID: "e5", Name: "Eve"}))
+	assert.Equal(t, int64(7), typed.appendLine) // d4 at row 5, e5 at 6
+	assert.ErrorIs(t, typed.Put(updater, account{Name: "nobody"}), ErrBlankKey)
+
+	// d4 is queued for row 5, not in the cache; putting it again rewrites
+	// that row rather than appending another
+	assert.NoError(t, typed.Put(updater, account{ID: "d4", Name: "Dee", Balance: 4}))
+	assert.Equal(t, int64(7), typed.appendLine)
+	assert.Equal(t, map[string]int64{"d4": 5, "e5": 6}, typed.appended)
+
+	assert.NoError(t, typed.Delete(updater, "c3"))
+	assert.Equal(t, int64(5), updater.Len()) // d4 twice, in the same row
+	assert.ErrorIs(t, typed.Delete(updater, "zz"), ErrLookupFailed)
+}
+
+func TestTypedDeleteBatch(t *testing.T) {
+	table := testTable([][]string{
+		{"ID", "Name", "Balance"},
+		{"a1", "Ann", "10"},
+		{"b2", "Bob", "20"},
+		{"c3", "Cy", "30"},
+	})
+	typed, err := NewTyped[account](table, "ID")
+	assert.NoError(t, err)
+	all, err := typed.All()
+	assert.NoError(t, err)
+	assert.Equal(t, []account{{"a1", "Ann", 10}, {"b2", "Bob", 20}, {"c3", "Cy", 30}}, all)
+
+	// rows 1 and 3 of the cache; the second delete goes to row 2 once
+	// the first has run, see ssdb.TestDeleteRowsBatch
+	updater := (&ssdb.SSDB{}).NewUpdater()
+	assert.NoError(t, typed.Delete(updater, "a1"))
+	assert.NoError(t, typed.Delete(updater, "c3"))
+	assert.Equal(t, int64(2), updater.Len())
+	assert.NoError(t, typed.Delete(updater, "a1")) // already going
+	assert.Equal(t, int64(2), updater.Len())
+}
+
+func TestTypedNumericKey(t *testing.T) {
+	type invoice struct {
+		No     int     `ssdb:"No"`
+		Amount float64 `ssdb:"Amount"`
+	}
+	table := testTable([][]string{
+		{"No", "Amount"},
+		{"1250", "1,250.50"},
+	})
+	typed, err := NewTyped[invoice](table, "No")
+	assert.NoError(t, err)
+	updater := (&ssdb.SSDB{}).NewUpdater()
+	assert.NoError(t, typed.Put(updater, invoice{No: 1250, Amount: 99}))
+	assert.Equal(t, int64(-1), typed.appendLine) // updated in place
+
+	// a float key is written as 1250.5 but shows as "1,250.50"
+	_, err = NewTyped[invoice](table, "Amount")
+	assert.ErrorIs(t, err, ErrKeyType)
+}
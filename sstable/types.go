@@ -8,6 +8,7 @@ package sstable
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/clucia/ssdb"
 )
@@ -15,7 +16,12 @@ import (
 type SSTable struct {
 	DB        *ssdb.SSDB
 	sheetName string
-	sheet     *ssdb.Sheet
+	sheet     *ssdb.Sheet // see currentSheet
+	sheetGen  uint64      // the cache generation sheet was looked up in
+	schema    *Schema     // see SetSchema
+	mu        sync.Mutex
+	idx       *tableIndex     // see index
+	indexes   map[string]bool // secondary indexes, to unique; see AddIndex
 }
 
 var ErrSheetNotFound = errors.New("sheet not found")
@@ -32,11 +38,35 @@ func Open(db *ssdb.SSDB, match string) (sstable *SSTable, err error) {
 		if sheetname == match {
 			found = true
 			sstable.sheet = sheet
+			sstable.sheetGen = db.Generation()
 			sstable.sheetName = sheetname
 		}
 	})
 	if !found {
-		sstable, err = nil, ErrSheetNotFound
+		return nil, ErrSheetNotFound
 	}
+	sstable.index()
 	return //
 }
+
+// currentSheet returns the table's sheet. A Loader replaces the cached
+// sheets, so it is looked up again whenever the cache has changed.
+func (sstable *SSTable) currentSheet() *ssdb.Sheet {
+	sstable.mu.Lock()
+	defer sstable.mu.Unlock()
+	return sstable.lookupSheet()
+}
+
+// lookupSheet is currentSheet for callers holding mu.
+func (sstable *SSTable) lookupSheet() *ssdb.Sheet {
+	if sstable.DB == nil {
+		return sstable.sheet
+	}
+	if generation := sstable.DB.Generation(); sstable.sheetGen != generation {
+		if sheet := sstable.DB.SheetLookup(sstable.sheetName); sheet != nil {
+			sstable.sheet = sheet
+		}
+		sstable.sheetGen = generation
+	}
+	return sstable.sheet
+}
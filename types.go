@@ -9,6 +9,7 @@ package ssdb
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/api/docs/v1"
@@ -21,6 +22,8 @@ type SSDB struct {
 	ctx           context.Context
 	dbTime        time.Time // used for caching
 	spreadsheet   *sheets.Spreadsheet
+	locale        *Locale       // see Locale, reset by Loader
+	generation    atomic.Uint64 // see Generation
 	DocsService   *docs.Service
 	SheetsService *sheets.Service
 	DriveService  *drive.Service
@@ -9,8 +9,10 @@ package ssdb
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
+	"time"
 
 	"google.golang.org/api/sheets/v4"
 )
@@ -19,19 +21,40 @@ type updateItem struct {
 	dbRange *DBRange
 	olddata [][]any
 	newdata [][]any
+	rowID   string // set for UpdateByID, see rowid.go
 }
 
+// requestItem is a raw batch request (formatting, dimension properties,
+// ...) queued alongside the value updates. apply, if set, is called with
+// the matching reply after the batch succeeds so the cache can be patched.
+type requestItem struct {
+	request *sheets.Request
+	apply   func(reply *sheets.Response)
+}
+
+// Skip, written as a value through the Updater, leaves the cell as it is.
+// It lets one Update write some cells of a range and not others:
+//
+//	updater.Update(row, [][]any{{"Ann", ssdb.Skip, 25}})
+var Skip = skipValue{}
+
+type skipValue struct{}
+
 type Updater struct {
 	sync.Mutex
-	ssdbHandle  *SSDB
-	updateQueue []*updateItem
-	submitted   bool
+	ssdbHandle    *SSDB
+	updateQueue   []*updateItem
+	requestQueue  []*requestItem
+	submitted     bool
+	guardFormulas bool // see GuardFormulas
+	reshaped      bool // rows were inserted or deleted, see DeleteRows
 }
 
 func (ssdbHandle *SSDB) NewUpdater() *Updater {
 	return &Updater{
-		ssdbHandle:  ssdbHandle,
-		updateQueue: make([]*updateItem, 0),
+		ssdbHandle:   ssdbHandle,
+		updateQueue:  make([]*updateItem, 0),
+		requestQueue: make([]*requestItem, 0),
 	}
 }
 
@@ -47,27 +70,56 @@ func (upd *Updater) Update(dbrange *DBRange, newvals [][]any) {
 	upd.Unlock()
 }
 
+func (upd *Updater) queueRequest(request *sheets.Request, apply func(reply *sheets.Response)) {
+	reqItem := &requestItem{
+		request: request,
+		apply:   apply,
+	}
+	upd.Lock()
+	upd.requestQueue = append(upd.requestQueue, reqItem)
+	upd.Unlock()
+}
+
 func (upd *Updater) Len() (l int64) {
 	upd.ssdbHandle.Lock()
 	defer upd.ssdbHandle.Unlock()
 
-	return int64(len(upd.updateQueue))
+	return int64(len(upd.updateQueue) + len(upd.requestQueue))
 }
 
 func (upd *Updater) Sync() (n int, err error) {
 	upd.ssdbHandle.Lock()
 	defer upd.ssdbHandle.Unlock()
 
-	n = len(upd.updateQueue)
+	n = len(upd.updateQueue) + len(upd.requestQueue)
 	if n == 0 {
 		return // Nothing to sync
 	}
+	moved, err := upd.resolveRowIDs()
+	if err != nil {
+		return 0, err
+	}
+	if moved {
+		// The cache has the moved rows where they were; reload it so
+		// they can be checked where they are now.
+		err = upd.ssdbHandle.Loader(upd.ssdbHandle.ctx)
+		if err != nil {
+			return 0, fmt.Errorf("unable to reload spreadsheet: %w", err)
+		}
+		if err = upd.rebind(); err != nil {
+			return 0, err
+		}
+	}
 	for _, elem := range upd.updateQueue {
 		if !elem.dbRange.sheet.CompareVals(elem.dbRange, elem.olddata) {
 			err = errors.New("data has changed since the update began")
 			return //
 		}
 	}
+	err = upd.checkFormulas()
+	if err != nil {
+		return 0, err
+	}
 	// Create and execute batch update request
 	batch := &sheets.BatchUpdateSpreadsheetRequest{}
 	for _, update := range upd.updateQueue {
@@ -95,22 +147,46 @@ func (upd *Updater) Sync() (n int, err error) {
 				},
 			)
 		}
-		rowData := BuildRowdataAny(update.newdata)
-		batch.Requests = append(batch.Requests,
-			&sheets.Request{
-				UpdateCells: &sheets.UpdateCellsRequest{
-					Range: update.dbRange.gridRange,
-					// show, memberName, text string, data any
-					Rows:   rowData,
-					Fields: "userEnteredValue,userEnteredFormat.backgroundColor",
-				},
-			},
-		)
+		rowData := buildRowdata(update.newdata, upd.ssdbHandle.Locale())
+		batch.Requests = append(batch.Requests, updateCellsRequests(update.dbRange.gridRange, update.newdata, rowData)...)
+		batch.Requests = append(batch.Requests, numberFormatRequests(update.dbRange, rowData)...)
 	}
-	_, err = upd.ssdbHandle.SheetsService.Spreadsheets.BatchUpdate(upd.ssdbHandle.SpreadsheetID, batch).Context(upd.ssdbHandle.ctx).Do()
+	reqBase := len(batch.Requests)
+	for _, item := range upd.requestQueue {
+		batch.Requests = append(batch.Requests, item.request)
+	}
+	bresp, err := upd.ssdbHandle.SheetsService.Spreadsheets.BatchUpdate(upd.ssdbHandle.SpreadsheetID, batch).Context(upd.ssdbHandle.ctx).Do()
 	if err != nil {
 		return 0, fmt.Errorf("unable to batch update spreadsheet: %w", err)
 	}
+	for i, item := range upd.requestQueue {
+		if item.apply == nil {
+			continue
+		}
+		var reply *sheets.Response
+		if bresp != nil && reqBase+i < len(bresp.Replies) {
+			reply = bresp.Replies[reqBase+i]
+		}
+		item.apply(reply)
+	}
+	upd.ssdbHandle.generation.Add(1)
+	upd.requestQueue = make([]*requestItem, 0)
+	reshaped := upd.reshaped
+	upd.reshaped = false
+	if len(upd.updateQueue) == 0 {
+		return // Nothing to read back
+	}
+	if moved || reshaped {
+		// Rows were rearranged, behind our back or by this batch; merging
+		// by position would scramble the cache, so reload it instead.
+		err = upd.ssdbHandle.Loader(upd.ssdbHandle.ctx)
+		if err != nil {
+			err = fmt.Errorf("unable to reload spreadsheet: %w", err)
+			return //
+		}
+		upd.updateQueue = make([]*updateItem, 0)
+		return //
+	}
 
 	// Create batch read request for updated ranges
 	ranges := make([]string, 0, len(upd.updateQueue))
@@ -135,11 +211,25 @@ func (upd *Updater) Sync() (n int, err error) {
 		err = fmt.Errorf("unable to merge data from sheet: %w", err)
 		return //
 	}
+	upd.keepWritten()
 	upd.updateQueue = make([]*updateItem, 0)
 	return //
 }
 
+// BuildRowdataAny converts values to cells the way an en_US spreadsheet
+// reads typed-in text: anything that looks like a number ("1,250.00",
+// "$5", "12%") is written as a number, everything else as text. Currency
+// and percentages get a CURRENCY or PERCENT number format. The Updater
+// uses the spreadsheet's own locale instead.
 func BuildRowdataAny(data [][]any) (rowData []*sheets.RowData) {
+	return buildRowdata(data, defaultLocale)
+}
+
+func BuildRowdata(data [][]string) (rowData []*sheets.RowData) {
+	return BuildRowdataAny(AnyIfy(data))
+}
+
+func buildRowdata(data [][]any, locale *Locale) (rowData []*sheets.RowData) {
 	xdim, ydim := getDimsAny(data)
 	rowData = []*sheets.RowData{}
 	for ypos := 0; ypos < ydim; ypos++ {
@@ -147,106 +237,115 @@ func BuildRowdataAny(data [][]any) (rowData []*sheets.RowData) {
 			Values: make([]*sheets.CellData, xdim),
 		})
 		for xpos := 0; xpos < len(data[ypos]); xpos++ {
-			var fld string
-			switch fld0 := data[ypos][xpos].(type) {
-			case string:
-				fld = fld0
-			default:
-				fld = fmt.Sprint(fld0)
-			}
-			switch {
-			case isBlank(fld):
-				rowData[ypos].Values[xpos] = &sheets.CellData{
-					UserEnteredFormat: &sheets.CellFormat{
-						BackgroundColor: AttnColor,
-					},
-				}
-			case isNumeric(fld):
-				numval, _ := strconv.ParseFloat(fld, 64)
-				rowData[ypos].Values[xpos] = &sheets.CellData{
-					UserEnteredValue: &sheets.ExtendedValue{
-						NumberValue: &numval,
-					},
-					UserEnteredFormat: &sheets.CellFormat{
-						BackgroundColor: AttnColor,
-					},
-					FormattedValue: fmt.Sprint(numval),
-				}
-			case isString(fld):
-				rowData[ypos].Values[xpos] = &sheets.CellData{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: &fld,
-					},
-					UserEnteredFormat: &sheets.CellFormat{
-						BackgroundColor: AttnColor,
-					},
-					FormattedValue: fld,
-				}
-			}
+			rowData[ypos].Values[xpos] = locale.cellData(data[ypos][xpos])
 		}
 	}
 	return //
 }
 
-func BuildRowdata(data [][]string) (rowData []*sheets.RowData) {
-	xdim, ydim := getDims(data)
-	rowData = []*sheets.RowData{}
-	for ypos := 0; ypos < ydim; ypos++ {
-		rowData = append(rowData, &sheets.RowData{
-			Values: make([]*sheets.CellData, xdim),
-		})
-		for xpos := 0; xpos < len(data[ypos]); xpos++ {
-			switch {
-			case isBlank(data[ypos][xpos]):
-				rowData[ypos].Values[xpos] = &sheets.CellData{
-					UserEnteredFormat: &sheets.CellFormat{
-						BackgroundColor: AttnColor,
-					},
-				}
-			case isNumeric(data[ypos][xpos]):
-				numval, _ := strconv.ParseFloat(data[ypos][xpos], 64)
-				rowData[ypos].Values[xpos] = &sheets.CellData{
-					UserEnteredValue: &sheets.ExtendedValue{
-						NumberValue: &numval,
-					},
-					UserEnteredFormat: &sheets.CellFormat{
-						BackgroundColor: AttnColor,
-					},
-					FormattedValue: fmt.Sprint(numval),
-				}
-			case isString(data[ypos][xpos]):
-				rowData[ypos].Values[xpos] = &sheets.CellData{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: &data[ypos][xpos],
-					},
-					UserEnteredFormat: &sheets.CellFormat{
-						BackgroundColor: AttnColor,
-					},
-					FormattedValue: data[ypos][xpos],
-				}
-			}
+// cellData converts a value written through the Updater to a cell.
+func (locale *Locale) cellData(v any) (cell *sheets.CellData) {
+	cell = &sheets.CellData{
+		UserEnteredFormat: &sheets.CellFormat{
+			BackgroundColor: AttnColor,
+		},
+	}
+	switch v := v.(type) {
+	case skipValue:
+		return nil // see updateCellsRequests
+	case RichText:
+		v.encode(cell)
+	case Link:
+		v.encode(cell)
+	case time.Time:
+		cell.UserEnteredValue = locale.extendedValue(v)
+		cell.UserEnteredFormat.NumberFormat = &sheets.NumberFormat{Type: "DATE_TIME"}
+	case string:
+		cell.UserEnteredValue = locale.extendedValue(v)
+		if _, kind, ok := locale.ParseNumber(v); ok && kind != NumberKind {
+			// "12%" is stored as 0.12; the format keeps it showing as 12%
+			cell.UserEnteredFormat.NumberFormat = &sheets.NumberFormat{Type: kind}
 		}
+	default:
+		cell.UserEnteredValue = locale.extendedValue(v)
 	}
 	return //
 }
 
-func getDimsAny(data [][]any) (x, y int) {
-	x = -1
-	y = -1
-	for j, v := range data {
-		for i, _ := range v {
-			if i > x {
-				x = i
-			}
+// extendedValue converts a value written through the Updater. Go numbers
+// are stored as numbers and bools as booleans; text is stored as a number
+// if it reads as one in this locale and as text otherwise. Blank text and
+// nil, such as the AVG of no cells, clear the cell.
+func (locale *Locale) extendedValue(v any) *sheets.ExtendedValue {
+	var fld string
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case Formula:
+		formula := string(v)
+		return &sheets.ExtendedValue{FormulaValue: &formula}
+	case time.Time:
+		serial := TimeToSerial(v, locale.Location)
+		return &sheets.ExtendedValue{NumberValue: &serial}
+	case float64:
+		return &sheets.ExtendedValue{NumberValue: &v}
+	case bool:
+		return &sheets.ExtendedValue{BoolValue: &v}
+	case float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		numval, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		return &sheets.ExtendedValue{NumberValue: &numval}
+	case string:
+		fld = v
+	default:
+		fld = fmt.Sprint(v)
+	}
+	if isBlank(fld) {
+		return nil
+	}
+	if numval, _, ok := locale.ParseNumber(fld); ok {
+		return &sheets.ExtendedValue{NumberValue: &numval}
+	}
+	return &sheets.ExtendedValue{StringValue: &fld}
+}
+
+// updateCellsRequests writes rowData, converted from data, to gridRange.
+// Cells written as Skip are left out, which takes one request for each run
+// of the other cells in a row.
+func updateCellsRequests(gridRange *sheets.GridRange, data [][]any, rowData []*sheets.RowData) (res []*sheets.Request) {
+	fields := RowdataFields(rowData)
+	request := func(gridRange *sheets.GridRange, rows []*sheets.RowData) *sheets.Request {
+		return &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Range:  apiGridRange(gridRange),
+				Rows:   rows,
+				Fields: fields,
+			},
 		}
-		if j > y {
-			y = j
+	}
+	if !slices.ContainsFunc(data, func(line []any) bool { return slices.Contains(line, any(Skip)) }) {
+		return []*sheets.Request{request(gridRange, rowData)}
+	}
+	skip := func(y, x int) bool { return x < len(data[y]) && data[y][x] == Skip }
+	for y, rd := range rowData {
+		row := gridRange.StartRowIndex + int64(y)
+		for start := 0; start < len(rd.Values); start++ {
+			if skip(y, start) {
+				continue
+			}
+			end := start
+			for end < len(rd.Values) && !skip(y, end) {
+				end++
+			}
+			col := gridRange.StartColumnIndex + int64(start)
+			run := GenRange(gridRange.SheetId, col, col+int64(end-start), row, row+1)
+			res = append(res, request(run, []*sheets.RowData{{Values: rd.Values[start:end]}}))
+			start = end
 		}
 	}
-	return x + 1, y + 1
+	return //
 }
 
-func getDims(data [][]string) (x, y int) {
+func getDimsAny(data [][]any) (x, y int) {
 	x = -1
 	y = -1
 	for j, v := range data {
@@ -266,33 +365,6 @@ func isBlank(s string) bool {
 	return len(s) == 0
 }
 
-func isString(s string) bool {
-	if isNumeric(s) {
-		return false
-	}
-	return len(s) > 0
-}
-
-func isNumeric(s string) bool {
-	switch {
-	case len(s) == 0:
-		return false
-	case s == "$":
-		return false
-	case s[0] == '$':
-		s = s[1:]
-	}
-	if len(s) == 0 {
-		return false
-	}
-	for _, c := range s {
-		if (c < '0' || c > '9') && c != '.' {
-			return false
-		}
-	}
-	return true
-}
-
 var AttnColor = &sheets.Color{
 	Red:   1.0,
 	Green: 1.0,
@@ -330,6 +402,7 @@ func (db *SSDB) Merge(rresp *sheets.BatchGetValuesResponse) error {
 	if rresp == nil {
 		return fmt.Errorf("response is nil")
 	}
+	defer db.generation.Add(1)
 	for _, vr := range rresp.ValueRanges {
 		if err := db.mergeValueRange(vr); err != nil {
 			return fmt.Errorf("failed to merge range %s: %w", vr.Range, err)
@@ -378,7 +451,7 @@ func (db *SSDB) updateCells(sheet *Sheet, rng *sheets.GridRange, values [][]inte
 			}
 
 			existingCell := sheet.Sheet.Data[0].RowData[actualRow].Values[actualCol]
-			sheet.Sheet.Data[0].RowData[actualRow].Values[actualCol] = genCell(existingCell, cellStr)
+			sheet.Sheet.Data[0].RowData[actualRow].Values[actualCol] = genCell(existingCell, cellStr, db.Locale())
 		}
 	}
 	return nil
@@ -458,24 +531,90 @@ func (db *SSDB) FindSheet(rng *sheets.GridRange) *Sheet {
 	return nil
 }
 
-func genCell(cell *sheets.CellData, cal string) (res *sheets.CellData) {
-
-	if cell.UserEnteredValue == nil {
-		cell.UserEnteredValue = &sheets.ExtendedValue{}
-	}
-	switch {
-	case isBlank(cal):
-	case isNumeric(cal):
-		numval, _ := strconv.ParseFloat(cal, 64)
-		cell.UserEnteredValue.NumberValue = &numval
-		cell.FormattedValue = fmt.Sprint(numval)
-	case isString(cal):
-		cell.UserEnteredValue.StringValue = &cal
-		cell.FormattedValue = cal
-	}
+// genCell stores text read back from the spreadsheet in cell. The text is
+// what the sheet shows, so it is parsed in the spreadsheet's locale.
+func genCell(cell *sheets.CellData, cal string, locale *Locale) (res *sheets.CellData) {
+	// The written value replaces both the entered and the effective value;
+	// leaving the old EffectiveValue would make typed reads return stale data.
+	val := locale.extendedValue(cal)
+	cell.UserEnteredValue = val
+	cell.EffectiveValue = val
+	cell.FormattedValue = cal
+	cell.TextFormatRuns = nil
+	cell.Hyperlink = ""
 	if cell.UserEnteredFormat == nil {
 		cell.UserEnteredFormat = &sheets.CellFormat{}
 	}
 	cell.UserEnteredFormat.BackgroundColor = AttnColor
 	return cell
 }
+
+// keepWritten puts what the read back after Sync can't show (formulas,
+// text runs, links, the serial number behind a date) into the cache, for
+// the cells just written.
+func (upd *Updater) keepWritten() {
+	locale := upd.ssdbHandle.Locale()
+	for _, update := range upd.updateQueue {
+		gridRange := update.dbRange.gridRange
+		update.dbRange.sheet.rangeIter(update.dbRange, func(row, col int64, cell *sheets.CellData) {
+			y, x := row-gridRange.StartRowIndex, col-gridRange.StartColumnIndex
+			if cell == nil || y >= int64(len(update.newdata)) || x >= int64(len(update.newdata[y])) {
+				return
+			}
+			switch update.newdata[y][x].(type) {
+			case Formula, RichText, Link:
+				written := locale.cellData(update.newdata[y][x])
+				cell.UserEnteredValue = written.UserEnteredValue
+				cell.TextFormatRuns = written.TextFormatRuns
+				cell.Hyperlink = written.Hyperlink
+			case time.Time:
+				written := locale.extendedValue(update.newdata[y][x])
+				cell.UserEnteredValue = written
+				cell.EffectiveValue = written
+			}
+		})
+	}
+}
+
+// numberFormatRequests gives the cells of rowData that were written with
+// a number format (a time.Time, or text such as "$5" or "12%") that format,
+// unless the cell already has a format of the same kind of its own. It's a
+// separate request so the other cells keep their formats.
+func numberFormatRequests(dbrange *DBRange, rowData []*sheets.RowData) (res []*sheets.Request) {
+	gridRange := dbrange.gridRange
+	for y, row := range rowData {
+		for x, cell := range row.Values {
+			if cell == nil || cell.UserEnteredFormat == nil || cell.UserEnteredFormat.NumberFormat == nil {
+				continue
+			}
+			r, c := gridRange.StartRowIndex+int64(y), gridRange.StartColumnIndex+int64(x)
+			if hasNumberFormat(dbrange.sheet.GetRowN(r).GetCellN(c), cell.UserEnteredFormat.NumberFormat.Type) {
+				continue
+			}
+			res = append(res, &sheets.Request{
+				RepeatCell: &sheets.RepeatCellRequest{
+					Range: GenRange(gridRange.SheetId, c, c+1, r, r+1),
+					Cell: &sheets.CellData{
+						UserEnteredFormat: &sheets.CellFormat{NumberFormat: cell.UserEnteredFormat.NumberFormat},
+					},
+					Fields: "userEnteredFormat.numberFormat",
+				},
+			})
+		}
+	}
+	return //
+}
+
+// hasNumberFormat reports whether cell has a number format of kind, where
+// any date or time format counts as DATE_TIME.
+func hasNumberFormat(cell *Cell, kind string) bool {
+	if cell == nil || cell.Cell == nil || cell.Cell.UserEnteredFormat == nil || cell.Cell.UserEnteredFormat.NumberFormat == nil {
+		return false
+	}
+	switch typ := cell.Cell.UserEnteredFormat.NumberFormat.Type; typ {
+	case "DATE", "TIME", "DATE_TIME":
+		return kind == "DATE_TIME"
+	default:
+		return typ == kind
+	}
+}